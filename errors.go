@@ -19,6 +19,12 @@ const (
 	ErrSetInvalidValue
 	ErrSetOverflow
 	ErrDoesNotImplementNotifyProvider
+	ErrInterpolationCycle
+	ErrInterpolationSyntax
+	ErrRequiredFieldMissing
+	ErrValidation
+	ErrSecretNotFound
+	ErrSecretSyntax
 )
 
 type Error uint8
@@ -55,6 +61,18 @@ func (e Error) Error() string {
 		return "set overflow"
 	case ErrDoesNotImplementNotifyProvider:
 		return "does not implement NotifyProvider extension interface"
+	case ErrInterpolationCycle:
+		return "interpolation cycle detected"
+	case ErrInterpolationSyntax:
+		return "invalid interpolation syntax"
+	case ErrRequiredFieldMissing:
+		return "required field is missing a value"
+	case ErrValidation:
+		return "validation failed"
+	case ErrSecretNotFound:
+		return "secret not found"
+	case ErrSecretSyntax:
+		return "invalid secret reference syntax"
 	}
 
 	return "unknown error"
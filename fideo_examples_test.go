@@ -3,6 +3,8 @@ package fido_test
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/krak3n/fido"
 	"github.com/krak3n/fido/providers/inmemory"
@@ -56,3 +58,229 @@ func ExampleFido_FetchWithContext_canceled() {
 	// Output:
 	// context canceled
 }
+
+func ExampleFido_Fetch_env() {
+	type Config struct {
+		Port string `fido:"port,env=EXAMPLE_PORT"`
+	}
+
+	var cfg Config
+
+	os.Setenv("EXAMPLE_PORT", "8080")
+	defer os.Unsetenv("EXAMPLE_PORT")
+
+	f, err := fido.New(&cfg)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	if err := f.Fetch(); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(cfg.Port)
+	// Output:
+	// 8080
+}
+
+func ExampleFido_Fetch_callbackHook() {
+	type Config struct {
+		Password string `fido:"password"`
+	}
+
+	var cfg Config
+
+	provider := inmemory.New()
+	provider.Add("password", "hunter2")
+
+	redact := fido.CallbackHook(func(next fido.Callback) fido.Callback {
+		return func(path fido.Path, value interface{}) error {
+			fmt.Printf("setting %s\n", path)
+
+			return next(path, value)
+		}
+	})
+
+	f, err := fido.New(&cfg, fido.WithCallbackHook(redact))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	if err := f.Fetch(provider); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(cfg.Password)
+	// Output:
+	// setting password
+	// hunter2
+}
+
+func ExampleFido_Fetch_fetchHook() {
+	type Config struct {
+		Foo string `fido:"foo"`
+	}
+
+	var cfg Config
+
+	provider := inmemory.New()
+	provider.Add("foo", "bar")
+
+	logging := fido.FetchHook(func(next fido.FetchFunc) fido.FetchFunc {
+		return func(ctx context.Context, provider fido.Provider, callback fido.Callback) error {
+			// Fido always also fetches its built-in env provider, so only log the provider this
+			// example cares about to keep the output deterministic.
+			if provider.String() == "inmemory" {
+				fmt.Printf("fetching %s\n", provider)
+			}
+
+			return next(ctx, provider, callback)
+		}
+	})
+
+	f, err := fido.New(&cfg, fido.WithFetchHook(logging))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	if err := f.Fetch(provider); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(cfg.Foo)
+	// Output:
+	// fetching inmemory
+	// bar
+}
+
+func ExampleFido_Fetch_transform() {
+	type Config struct {
+		Name string `fido:"name,transform=trim"`
+	}
+
+	var cfg Config
+
+	provider := inmemory.New()
+	provider.Add("name", "  gopher  ")
+
+	trim := fido.Transform(func(v interface{}) (interface{}, error) {
+		s, ok := v.(string)
+		if !ok {
+			return v, nil
+		}
+
+		return strings.TrimSpace(s), nil
+	})
+
+	f, err := fido.New(&cfg, fido.WithTransform("trim", trim))
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	if err := f.Fetch(provider); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Printf("%q\n", cfg.Name)
+	// Output:
+	// "gopher"
+}
+
+func ExampleFido_Fetch_alias() {
+	type Config struct {
+		Port string `fido:"port,alias=http_port"`
+	}
+
+	var cfg Config
+
+	provider := inmemory.New()
+	provider.Add("http_port", "8080")
+
+	f, err := fido.New(&cfg)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	if err := f.Fetch(provider); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(cfg.Port)
+	// Output:
+	// 8080
+}
+
+func ExampleFido_Diff() {
+	type Config struct {
+		Foo string `fido:"foo"`
+	}
+
+	var cfg Config
+
+	provider := inmemory.New()
+	provider.Add("foo", "bar")
+
+	f, err := fido.New(&cfg)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	diff, err := f.Diff(provider)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(len(diff), diff[0].Path, diff[0].Old, diff[0].New)
+	fmt.Println(cfg.Foo == "")
+	// Output:
+	// 1 foo  bar
+	// true
+}
+
+func ExampleFido_Fetch_slice() {
+	type Config struct {
+		Tags []string `fido:"tags"`
+	}
+
+	var cfg Config
+
+	provider := inmemory.New()
+	provider.Add("tags", []string{"a", "b"})
+
+	f, err := fido.New(&cfg)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	if err := f.Fetch(provider); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(cfg.Tags)
+	// Output:
+	// [a b]
+}
+
+func ExampleFido_Fetch_map() {
+	type Config struct {
+		Labels map[string]string `fido:"labels"`
+	}
+
+	var cfg Config
+
+	provider := inmemory.New()
+	provider.Add("labels", map[string]string{"env": "prod"})
+
+	f, err := fido.New(&cfg)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	if err := f.Fetch(provider); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(cfg.Labels)
+	// Output:
+	// map[env:prod]
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"reflect"
 	"sync"
+	"time"
 )
 
 // DefaultStructTag is the struct tag fido looks for to populate values from providers.
@@ -30,6 +32,39 @@ type Options struct {
 	// ErrorOnMissingTag configures Fido to return an error if the Fido struct tag is not found on a
 	// destination struct field. Default: true.
 	ErrorOnMissingTag bool
+	// DecodeHooks are consulted, in registration order, before setValue falls back to reflection
+	// based coercion, allowing callers to register custom from/to type conversions.
+	DecodeHooks []DecodeHookFunc
+	// Interpolate enables the ${...} interpolation pass that runs after every Fetch. Default: false.
+	Interpolate bool
+	// MergePolicy controls how a field's value is combined when more than one Provider sets it.
+	// A fido:"path,merge=..." struct tag overrides this for an individual field. Default: MergeOverride.
+	MergePolicy MergePolicy
+	// SecretResolvers are consulted, in registration order, against every string value a Provider
+	// emits, letting a Provider give Fido a reference or ciphertext (e.g. env:DB_PASSWORD or
+	// vault://secret/data/db#password) that is resolved to its plaintext before the field is set.
+	SecretResolvers []SecretResolver
+	// WatchInterval is the poll interval WatchWithContext uses for a Provider that implements
+	// neither NotifyProvider nor PollProvider. A Provider implementing PollProvider overrides this.
+	// Default: DefaultWatcherInterval.
+	WatchInterval time.Duration
+	// FetchHooks are composed, in registration order, around provider.Values for every fetch of a
+	// Provider, letting callers add cross-cutting behaviour such as logging, metrics or a tracing
+	// span around the call.
+	FetchHooks []FetchHook
+	// CallbackHooks are composed, in registration order, around the Callback a Provider is given,
+	// letting callers observe or transform every value, e.g. redacting a secret before it reaches
+	// subscribers, before it is staged onto its destination field.
+	CallbackHooks []CallbackHook
+	// Transforms are registered by name with WithTransform, consulted for every field whose
+	// transform= tag option, or TransformStructTag, names them, converting or rejecting a value a
+	// Provider gives Fido before it is staged onto its destination field.
+	Transforms map[string]Transform
+	// AtomicFetch switches f.writer from writing each value straight to its field as a Provider
+	// gives it, to staging it into a batch that is only applied once the Provider's Values call
+	// returns nil, so a Provider that errors partway through never leaves some fields updated ahead
+	// of others. Default: false.
+	AtomicFetch bool
 }
 
 // DefaultOptions returns the default configuration options for Fido.
@@ -41,6 +76,8 @@ func DefaultOptions() Options {
 		ErrorOnMissingTag:    true,
 		ErrorOnFieldNotFound: false,
 		StructTag:            DefaultStructTag,
+		MergePolicy:          MergeOverride,
+		WatchInterval:        DefaultWatcherInterval,
 	}
 }
 
@@ -94,10 +131,94 @@ func SetErrorOnMissingTag(err bool) Option {
 	})
 }
 
+// DecodeHookFunc converts a value of type from into a value suitable for type to, returning false
+// if the hook does not apply to this conversion. Registered hooks are consulted by setValue before
+// it falls back to reflection based coercion, e.g. converting a string to a time.Duration via
+// time.ParseDuration.
+type DecodeHookFunc func(from reflect.Type, to reflect.Type, v interface{}) (interface{}, bool, error)
+
+// WithDecodeHook registers a DecodeHookFunc with Fido. Hooks are tried in registration order and
+// the first one to report a match wins.
+func WithDecodeHook(fn DecodeHookFunc) Option {
+	return OptionFunc(func(o *Options) {
+		o.DecodeHooks = append(o.DecodeHooks, fn)
+	})
+}
+
+// WithInterpolation enables the ${...} interpolation pass. Once every provider given to Fetch has
+// written its values, Fido expands any $$ (escaped to a literal $), ${path.to.field} (resolved
+// against other fields by their dotted Path) or ${env:VAR} (resolved via os.Getenv) references
+// found in string and []string field values, falling back to a default with ${name:-default}.
+// Default: disabled.
+func WithInterpolation() Option {
+	return OptionFunc(func(o *Options) {
+		o.Interpolate = true
+	})
+}
+
+// WithMergePolicy configures Fido's MergePolicy.
+func WithMergePolicy(p MergePolicy) Option {
+	return OptionFunc(func(o *Options) {
+		o.MergePolicy = p
+	})
+}
+
 // Callback is a function given to a Provider to call when it has values to give to Fido for
 // processing.
 type Callback func(path Path, value interface{}) error
 
+// A CallbackHook wraps a Callback with cross-cutting behaviour applied to every value a Provider
+// gives Fido before it reaches field.Set, e.g. redacting a secret before it is published to
+// subscribers, metrics, or per-field type coercion. Hooks are composed, in registration order,
+// around the Callback fetch builds for a single Provider invocation.
+type CallbackHook func(next Callback) Callback
+
+// WithCallbackHook registers a CallbackHook with Fido. Hooks are composed, in registration order,
+// around the Callback given to every Provider.
+func WithCallbackHook(hook CallbackHook) Option {
+	return OptionFunc(func(o *Options) {
+		o.CallbackHooks = append(o.CallbackHooks, hook)
+	})
+}
+
+// A FetchFunc performs a single Provider fetch, the unit of work a FetchHook wraps.
+type FetchFunc func(ctx context.Context, provider Provider, callback Callback) error
+
+// A FetchHook wraps a FetchFunc with cross-cutting behaviour around a Provider's Values call, e.g.
+// logging, metrics or a tracing span. Hooks are composed, in registration order, around fetch's own
+// call to provider.Values.
+type FetchHook func(next FetchFunc) FetchFunc
+
+// WithFetchHook registers a FetchHook with Fido. Hooks are composed, in registration order, around
+// provider.Values for every fetch of a Provider.
+func WithFetchHook(hook FetchHook) Option {
+	return OptionFunc(func(o *Options) {
+		o.FetchHooks = append(o.FetchHooks, hook)
+	})
+}
+
+// WithTransform registers a named Transform with Fido, consulted for every field whose transform=
+// tag option, or TransformStructTag, references name. Registering a name already in use overwrites
+// it.
+func WithTransform(name string, fn Transform) Option {
+	return OptionFunc(func(o *Options) {
+		if o.Transforms == nil {
+			o.Transforms = make(map[string]Transform)
+		}
+
+		o.Transforms[name] = fn
+	})
+}
+
+// WithAtomicFetch configures Fido's AtomicFetch behaviour. Set to true to have f.writer stage every
+// value a Provider gives it into a batch applied only once that Provider's Values call returns nil,
+// rather than writing each value to its field as it arrives.
+func WithAtomicFetch(enabled bool) Option {
+	return OptionFunc(func(o *Options) {
+		o.AtomicFetch = enabled
+	})
+}
+
 // FieldUpdate holds meta data about a change to a fields value.
 // Old and New values are not guarantee to be populated. Always check the value of Err.
 type FieldUpdate struct {
@@ -105,6 +226,22 @@ type FieldUpdate struct {
 	Old      interface{} // The previous value
 	New      interface{} // The new value
 	Provider Provider    // The provider that set the value
+	Secret   bool        // Whether the field carries the secret tag option
+}
+
+// redacted is the placeholder String substitutes for Old and New when Secret is set.
+const redacted = "***"
+
+// String formats u for logging, substituting redacted for Old and New when Secret is set so a
+// secret tagged field's value is never written out verbatim by a subscriber's logging.
+func (u *FieldUpdate) String() string {
+	oldVal, newVal := u.Old, u.New
+
+	if u.Secret {
+		oldVal, newVal = redacted, redacted
+	}
+
+	return fmt.Sprintf("%s: %v -> %v", u.Path, oldVal, newVal)
 }
 
 // A Notification holds meta data about a change to a field.
@@ -123,6 +260,18 @@ func (e *FieldUpdateError) Updates() ([]*FieldUpdate, error) {
 	return nil, e.Err
 }
 
+// A FieldValidationError satisfies the Notification interface and is published instead of
+// FieldUpdates when an AutoUpdate reload's values fail Validate, so subscribers can tell a bad
+// reload apart from a Provider or transport error reported via FieldUpdateError.
+type FieldValidationError struct {
+	Err error
+}
+
+// Updates returns a nil slice of *FieldUpdate and the Error that occured.
+func (e *FieldValidationError) Updates() ([]*FieldUpdate, error) {
+	return nil, e.Err
+}
+
 // FieldUpdates is a slice of pointers to FieldUpdate values. It implements the Notification
 // interface allowing FieldUpdates to be published to subscribers.
 type FieldUpdates []*FieldUpdate
@@ -134,18 +283,26 @@ func (u FieldUpdates) Updates() ([]*FieldUpdate, error) {
 
 // Fido is a extensible configuration loader.
 type Fido struct {
+	ctx         context.Context
+	cancel      context.CancelFunc
 	wg          sync.WaitGroup
 	subscribers []chan Notification
 	providers   providers
 	watching    providers
 	fields      fields
+	structs     []reflect.Value
 	options     Options
 }
 
 // New constructs a new Fido.
 func New(dst interface{}, opts ...Option) (*Fido, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
 	f := &Fido{
+		ctx:       ctx,
+		cancel:    cancel,
 		providers: make(providers),
+		watching:  make(providers),
 		fields:    make(fields),
 		options:   DefaultOptions(),
 	}
@@ -154,12 +311,128 @@ func New(dst interface{}, opts ...Option) (*Fido, error) {
 		opt.apply(&f.options)
 	}
 
-	return f, f.hydrate([]string{}, reflect.ValueOf(dst))
+	if err := f.hydrate([]string{}, reflect.ValueOf(dst), tagOptions{merge: f.options.MergePolicy}); err != nil {
+		return f, err
+	}
+
+	// The built-in env provider is always added first so it holds the lowest priority: a field it
+	// resolves from its env= tag option can still be overridden by any provider added afterwards.
+	f.providers.add(newEnvProvider(f.fields))
+
+	return f, nil
+}
+
+// envProvider is Fido's built-in Provider, added automatically by New, that resolves the env=<NAME>
+// tag option of a field directly against the process environment, independently of any path based
+// name mapping a provider such as providers/env applies.
+type envProvider struct {
+	fields fields
+}
+
+func newEnvProvider(fields fields) *envProvider {
+	return &envProvider{fields: fields}
 }
 
-// Add adds providers to Fido.
-func (f *Fido) Add(providers ...Provider) {
-	f.providers.add(providers...)
+func (p *envProvider) String() string {
+	return "env"
+}
+
+// Values writes the process environment variable named by the env= tag option of every field that
+// has one and is currently set, skipping fields with no env= option or whose named variable is
+// unset.
+func (p *envProvider) Values(ctx context.Context, writer Writer) error {
+	for _, fld := range p.fields {
+		tf, ok := fld.(taggedField)
+		if !ok {
+			continue
+		}
+
+		name, ok := tf.envHint()
+		if !ok {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		if err := writer.Write(fld.Path(), v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Add adds providers to Fido. Any provider added for the first time that implements the optional
+// PathProvider extension interface is streamed the KnownPath of every field Fido discovered while
+// hydrating the destination struct, letting it subscribe to, or pre-seed, only the keys the struct
+// actually cares about instead of scanning or dumping its entire source.
+func (f *Fido) Add(items ...Provider) {
+	for _, provider := range items {
+		if _, ok := f.providers[provider]; ok {
+			continue
+		}
+
+		f.providers.add(provider)
+
+		if pp, ok := unwrapPriority(provider).(PathProvider); ok {
+			f.pushPaths(pp)
+		}
+	}
+}
+
+// pushPaths streams the KnownPath of every field registered by hydrate to provider, tracked by
+// f.wg so Close waits for it to finish. Sending and the provider's own consumption of them each run
+// on their own goroutine since Paths does not report when, or whether, it is done reading.
+func (f *Fido) pushPaths(provider PathProvider) {
+	ch := make(chan KnownPath)
+
+	f.wg.Add(1)
+
+	go func() {
+		defer f.wg.Done()
+
+		provider.Paths(ch)
+	}()
+
+	f.wg.Add(1)
+
+	go func() {
+		defer f.wg.Done()
+		defer close(ch)
+
+		for _, fld := range f.fields {
+			kp := KnownPath{Path: fld.Path()}
+
+			if tf, ok := fld.(taggedField); ok {
+				kp.Default, kp.HasDefault = tf.defaultValue()
+				kp.Required = tf.requiredField()
+			}
+
+			ch <- kp
+		}
+	}()
+}
+
+// Paths returns the Path of every field Fido has registered from the destination struct, in no
+// particular order. Providers that only want to resolve known configuration keys, rather than
+// blindly scanning their source, can use this to know what to look up.
+func (f *Fido) Paths() []Path {
+	paths := make([]Path, 0, len(f.fields))
+
+	for _, fld := range f.fields {
+		paths = append(paths, fld.Path())
+	}
+
+	return paths
 }
 
 // Fetch fetches configuration values from the given providers with a background context.
@@ -191,7 +464,35 @@ func (f *Fido) FetchWithContext(ctx context.Context, providers ...Provider) erro
 		}
 	}
 
-	return nil
+	if err := f.validate(); err != nil {
+		return err
+	}
+
+	if f.options.Interpolate {
+		if err := interpolate(f.fields); err != nil {
+			return err
+		}
+	}
+
+	return f.validateStructs()
+}
+
+// Validate runs the struct tag driven field validation and any Validator implemented by the
+// destination struct, or a nested struct field, against the values currently held. It runs
+// automatically at the end of FetchWithContext, but can also be called directly, e.g. after an
+// AutoUpdate reload or a manual mutation of the destination struct.
+func (f *Fido) Validate(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if err := f.validate(); err != nil {
+		return err
+	}
+
+	return f.validateStructs()
 }
 
 // Watch starts watching providers that support the NotifyProvider optional extension interface.
@@ -199,25 +500,34 @@ func (f *Fido) Watch(providers ...Provider) error {
 	return f.FetchWithContext(context.Background())
 }
 
-// WatchWithContext starts watching providers that support the NotifyProvider optional extension
-// interface with the provided context.
+// WatchWithContext starts watching providers. A provider implementing NotifyProvider is watched via
+// its own push notifications; any other provider is watched by an internal poller that re-fetches it
+// on an interval, so that providers with only pull semantics (e.g. files, HTTP, etcd) still
+// participate in reload. The given context is not consulted: the notify/poll goroutines it starts
+// run on Fido's own context for as long as Fido does, stopped by Close rather than by the caller's
+// context, since the common f.Fetch(provider); defer f.Close() idiom passes
+// context.Background() and would otherwise watch forever with no way to stop.
 func (f *Fido) WatchWithContext(ctx context.Context, providers ...Provider) error {
 	f.Add(providers...)
 
 	for provider := range f.providers {
-		if notifier, ok := provider.(NotifyProvider); ok {
-			if _, ok := f.watching[provider]; !ok {
-				ch, err := notifier.Notify()
-				if err != nil {
-					return fmt.Errorf("%w: failed to start provider %s notifier", err, provider)
-				}
+		if _, ok := f.watching[provider]; ok {
+			continue
+		}
 
-				f.watching.add(provider)
-				f.wg.Add(1)
+		if notifier, ok := unwrapPriority(provider).(NotifyProvider); ok {
+			f.watching.add(provider)
+			f.wg.Add(1)
 
-				go f.watch(ctx, ch)
-			}
+			go f.notify(f.ctx, provider, notifier)
+
+			continue
 		}
+
+		f.watching.add(provider)
+		f.wg.Add(1)
+
+		go f.poll(f.ctx, provider, f.pollInterval(provider))
 	}
 
 	return nil
@@ -232,11 +542,17 @@ func (f *Fido) Subscribe() <-chan Notification {
 	return ch
 }
 
-// Close calls the close method on any providers that implement the optional CloseProvider optional
-// extension interface. It will also close any subscriber channels that are currently open.
+// Close cancels the context every notify/poll goroutine started by Watch/WatchWithContext runs
+// against, then calls the close method on any providers that implement the optional CloseProvider
+// optional extension interface, before waiting for every one of those goroutines to return. It will
+// also close any subscriber channels that are currently open.
 func (f *Fido) Close() error {
+	if f.cancel != nil {
+		f.cancel()
+	}
+
 	for provider := range f.providers {
-		if closer, ok := provider.(CloseProvider); ok {
+		if closer, ok := unwrapPriority(provider).(CloseProvider); ok {
 			if err := closer.Close(); err != nil {
 				return err
 			}
@@ -252,8 +568,27 @@ func (f *Fido) Close() error {
 	return nil
 }
 
-// hydrate recursively populates the field map, mapping paths to struct fields.
-func (f *Fido) hydrate(p Path, v reflect.Value) error {
+// tagOptions carries the struct tag options of the field being descended into down through
+// hydrate's recursion, since a field's tag is read one level above where the field itself is
+// registered, so that the leaf field constructed for it picks them up.
+type tagOptions struct {
+	merge     MergePolicy
+	unique    bool
+	def       string
+	hasDef    bool
+	required  bool
+	validates []string
+	env       string
+	aliases   []string
+	transform []string
+	secret    bool
+	separator string
+}
+
+// hydrate recursively populates the field map, mapping paths to struct fields. opts carries the
+// merge policy, default, required, validate, env, alias, transform, secret and sep tag options
+// resolved against the Fido level defaults for the field being descended into.
+func (f *Fido) hydrate(p Path, v reflect.Value, opts tagOptions) error {
 	if len(p) == 0 {
 		switch {
 		case !v.IsValid():
@@ -270,14 +605,35 @@ func (f *Fido) hydrate(p Path, v reflect.Value) error {
 	}
 
 	if v.Kind() != reflect.Struct {
-		f.fields.set(p, &field{
-			path:  p,
-			value: v,
-		})
+		fld := &field{
+			path:       p,
+			value:      v,
+			hooks:      f.options.DecodeHooks,
+			policy:     opts.merge,
+			unique:     opts.unique,
+			def:        opts.def,
+			hasDefault: opts.hasDef,
+			required:   opts.required,
+			validates:  opts.validates,
+			env:        opts.env,
+			transforms: opts.transform,
+			secret:     opts.secret,
+			separator:  opts.separator,
+		}
+
+		f.fields.set(p, fld)
+
+		for _, alias := range opts.aliases {
+			f.fields.set(append(p[:len(p)-1:len(p)-1], alias), fld)
+		}
 
 		return nil
 	}
 
+	if v.CanAddr() {
+		f.structs = append(f.structs, v)
+	}
+
 	for i := 0; i < v.NumField(); i++ {
 		fv := v.Field(i)
 		ft := v.Type().Field(i)
@@ -291,7 +647,32 @@ func (f *Fido) hydrate(p Path, v reflect.Value) error {
 			continue
 		}
 
-		if err := f.hydrate(append(p, tag.Name), fv); err != nil {
+		policy, fieldUnique := mergePolicyFor(tag, f.options.MergePolicy)
+
+		childOpts := tagOptions{
+			merge:     policy,
+			unique:    fieldUnique,
+			def:       tag.Default,
+			hasDef:    tag.HasDefault,
+			required:  tag.Required,
+			validates: tag.Validate,
+			env:       tag.Env,
+			aliases:   tag.Aliases,
+			transform: tag.Transform,
+			secret:    tag.Secret,
+			separator: tag.Separator,
+		}
+
+		if required, rules, ok := LookupValidateTag(ft); ok {
+			childOpts.required = childOpts.required || required
+			childOpts.validates = append(childOpts.validates, rules...)
+		}
+
+		if names, ok := LookupTransformTag(ft); ok {
+			childOpts.transform = append(childOpts.transform, names...)
+		}
+
+		if err := f.hydrate(append(p, tag.Name), fv, childOpts); err != nil {
 			return fmt.Errorf("%w: failed parse struct tag for %s", err, ft.Name)
 		}
 	}
@@ -306,36 +687,155 @@ func (f *Fido) publish(notification Notification) {
 	}
 }
 
-// watch continiously pulls values from the given channel until the context is complete or the
-// channel is closed. If AutoUpdate is enabled fetch will be called for the Provider given on the
-// channel reloading configuration values from that Provider.
-func (f *Fido) watch(ctx context.Context, ch <-chan Provider) {
+// notify runs notifier's push-based Notify loop for as long as ctx is not done, writing every value
+// it streams to its destination field through initMapMiddleware, enforcePriorityMiddleware and
+// transformMiddleware, which apply the same map initialisation, priority check and transforms that
+// callback applies to a fetched value; the configured SecretResolvers are not run on a pushed
+// value. If AutoUpdate is enabled, each value
+// actually changed is followed by Validate, publishing a FieldValidationError rather than silently
+// leaving it in place if validation fails; a value that fails to write, or Notify itself returning
+// an error other than context cancellation, is published as a FieldUpdateError.
+func (f *Fido) notify(ctx context.Context, provider Provider, notifier NotifyProvider) {
 	defer f.wg.Done()
 
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case provider, ok := <-ch:
-			if !ok {
-				return // Channel has closed
-			}
+	updatesCh := make(chan *FieldUpdate)
+	doneCh := make(chan struct{})
+
+	go func() {
+		defer close(doneCh)
+
+		for update := range updatesCh {
+			f.publish(FieldUpdates{update})
 
 			if f.options.AutoUpdate {
-				if err := f.fetch(ctx, provider); err != nil {
-					f.publish(&FieldUpdateError{
+				if err := f.Validate(ctx); err != nil {
+					f.publish(&FieldValidationError{
 						Err: err,
 					})
 				}
 			}
 		}
+	}()
+
+	writer := WrapWriter(
+		f.writer(ctx, provider),
+		f.initMapMiddleware(),
+		f.enforcePriorityMiddleware(provider),
+		f.transformMiddleware(),
+		f.notificationMiddleware(provider, updatesCh),
+	)
+
+	err := notifier.Notify(ctx, writer)
+
+	close(updatesCh)
+	<-doneCh
+
+	if err != nil && !errors.Is(err, context.Canceled) {
+		f.publish(&FieldUpdateError{
+			Err: fmt.Errorf("%w: notify failed for provider %s", err, provider),
+		})
+	}
+}
+
+// validate runs once every provider given to FetchWithContext has written its values. For each
+// registered field it fills in the default= tag option if the field is still unset, collects a
+// wrapped ErrRequiredFieldMissing if required is set and the field is still unset, then runs any
+// validate= predicates against the field's final value, closing the loop between a value being
+// present and a value being acceptable. Every required field missing a value is reported together,
+// via errors.Join, rather than failing on the first one found, so a caller sees the whole set of
+// fields to fix in one pass.
+func (f *Fido) validate() error {
+	var missing []error
+
+	for _, fld := range f.fields {
+		tf, ok := fld.(taggedField)
+		if !ok {
+			continue
+		}
+
+		if fld.Provider() == nil {
+			switch def, hasDefault := tf.defaultValue(); {
+			case hasDefault:
+				if err := setValue(fld.Value(), def); err != nil {
+					return fmt.Errorf("%w: failed to set default for %s", err, fld.Path())
+				}
+			case tf.requiredField():
+				missing = append(missing, fmt.Errorf("%w: %s", ErrRequiredFieldMissing, fld.Path()))
+
+				continue
+			default:
+				// No provider has written this field, and it's neither required nor defaulted, so
+				// there's no value to validate yet.
+				continue
+			}
+		}
+
+		if err := validateFieldRules(fld); err != nil {
+			return err
+		}
+	}
+
+	if len(missing) > 0 {
+		return errors.Join(missing...)
+	}
+
+	return nil
+}
+
+// validateStructs invokes Validate on the destination struct, and any nested struct field,
+// recorded by hydrate that implements the Validator interface, letting callers perform cross-field
+// checks Fido's own struct tag driven validation can't express.
+func (f *Fido) validateStructs() error {
+	for _, v := range f.structs {
+		vl, ok := v.Addr().Interface().(Validator)
+		if !ok {
+			continue
+		}
+
+		if err := vl.Validate(); err != nil {
+			return fmt.Errorf("%w: %s", ErrValidation, err)
+		}
 	}
+
+	return nil
+}
+
+// fetch fetches values from the given provider. Every value the provider sends is staged rather
+// than written straight to its field, so a provider that errors partway through, or whose reload
+// fails field-level validation, leaves the destination struct exactly as it was: fetch commits the
+// whole batch only once the provider has returned without error, and discards or reverts it
+// otherwise. Update notifications are published to notification subscribers once collected by
+// stage.
+func (f *Fido) fetch(ctx context.Context, provider Provider) error {
+	updates, err := f.stage(ctx, provider)
+	if err != nil {
+		f.discard(updates)
+
+		f.publish(&FieldUpdateError{
+			Err: fmt.Errorf("%w: staged values discarded for provider %s", err, provider),
+		})
+
+		return err
+	}
+
+	if err := f.commit(updates); err != nil {
+		f.publish(&FieldUpdateError{Err: err})
+
+		return err
+	}
+
+	f.publish(updates)
+
+	return nil
 }
 
-// fetch fetches values from the given provider. Update notifications are pumped onto an internal
-// channel and passed to publish to be sent to notification subscribers.
-// A named return value is used to catch and return a wrapped recover error on panic.
-func (f *Fido) fetch(ctx context.Context, provider Provider) (err error) {
+// stage runs provider through the configured CallbackHooks and FetchHooks and collects every
+// FieldUpdate it stages via callback onto an internal channel, recovering and wrapping a panic into
+// the returned error. It leaves every field exactly as Stage left it; the caller decides whether to
+// commit them, via commit, or undo them, via discard. The configured CallbackHooks are composed
+// around the Callback given to the provider, and the configured FetchHooks are composed around the
+// call to provider.Values itself, both in registration order.
+func (f *Fido) stage(ctx context.Context, provider Provider) (updates FieldUpdates, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			switch r := r.(type) {
@@ -347,8 +847,6 @@ func (f *Fido) fetch(ctx context.Context, provider Provider) (err error) {
 		}
 	}()
 
-	var updates FieldUpdates
-
 	var (
 		updatesCh = make(chan *FieldUpdate)
 		doneCh    = make(chan struct{})
@@ -362,24 +860,151 @@ func (f *Fido) fetch(ctx context.Context, provider Provider) (err error) {
 		}
 	}()
 
-	err = provider.Values(ctx, f.callback(provider, updatesCh))
+	cb := f.callback(provider, updatesCh)
+
+	for i := len(f.options.CallbackHooks) - 1; i >= 0; i-- {
+		cb = f.options.CallbackHooks[i](cb)
+	}
+
+	fn := FetchFunc(func(ctx context.Context, provider Provider, callback Callback) error {
+		return provider.Values(ctx, WriterFunc(callback))
+	})
+
+	for i := len(f.options.FetchHooks) - 1; i >= 0; i-- {
+		fn = f.options.FetchHooks[i](fn)
+	}
+
+	err = fn(ctx, provider, cb)
 
 	close(updatesCh)
 
 	<-doneCh
 
-	if err == nil {
-		f.publish(updates)
+	return updates, err
+}
+
+// Diff runs the full provider pipeline — CallbackHooks, FetchHooks, priority enforcement, secret
+// resolution and transforms, exactly as Fetch would — against each given provider, but never
+// commits a field: every value staged is rolled back once collected, leaving the destination struct
+// untouched. It's useful for a CLI that wants to preview what a Fetch would change against a
+// running config. A provider that errors aborts the remaining providers; the FieldUpdates already
+// collected are returned alongside the error.
+func (f *Fido) Diff(providers ...Provider) ([]FieldUpdate, error) {
+	return f.DiffWithContext(context.Background(), providers...)
+}
+
+// DiffWithContext is Diff with the provided context.
+func (f *Fido) DiffWithContext(ctx context.Context, providers ...Provider) ([]FieldUpdate, error) {
+	f.Add(providers...)
+
+	var diff []FieldUpdate
+
+	for provider := range f.providers {
+		select {
+		case <-ctx.Done():
+			return diff, ctx.Err()
+		default:
+		}
+
+		updates, err := f.stage(ctx, provider)
+
+		f.discard(updates)
+
+		for _, update := range updates {
+			diff = append(diff, *update)
+		}
+
+		if err != nil {
+			return diff, err
+		}
+	}
+
+	return diff, nil
+}
+
+// commit writes every field named in updates to its destination value, via Field.Commit, checking
+// each one's validate= rules as soon as it lands. If a field fails to commit, or fails validation,
+// every field committed earlier in the same batch is reverted to the value captured in its
+// FieldUpdate.Old and the rest are discarded, so a batch from one provider is never left half
+// applied.
+func (f *Fido) commit(updates FieldUpdates) error {
+	for i, update := range updates {
+		fld, ok := f.fields.get(update.Path)
+		if !ok {
+			continue
+		}
+
+		if err := fld.Commit(); err != nil {
+			f.discard(updates[i+1:])
+			f.revert(updates[:i])
+
+			return fmt.Errorf("%w: failed to commit field %s", err, update.Path)
+		}
+
+		if err := validateFieldRules(fld); err != nil {
+			f.discard(updates[i+1:])
+
+			if rerr := fld.Revert(update.Old); rerr != nil {
+				return fmt.Errorf("%w: failed to revert field %s after failed validation", rerr, update.Path)
+			}
+
+			f.revert(updates[:i])
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// revert restores every field named in updates to the value captured in its FieldUpdate.Old,
+// undoing a Commit made earlier in the same batch.
+func (f *Fido) revert(updates FieldUpdates) error {
+	for _, update := range updates {
+		fld, ok := f.fields.get(update.Path)
+		if !ok {
+			continue
+		}
+
+		if err := fld.Revert(update.Old); err != nil {
+			return fmt.Errorf("%w: failed to revert field %s", err, update.Path)
+		}
 	}
 
-	return err
+	return nil
+}
+
+// discard drops the value staged for every field named in updates that commit has not yet reached
+// in this batch.
+func (f *Fido) discard(updates FieldUpdates) {
+	for _, update := range updates {
+		if fld, ok := f.fields.get(update.Path); ok {
+			fld.Rollback()
+		}
+	}
 }
 
 // callback returns the callback function gigven to a provider to call when it wishes to send
-// a configuration value to Fido. It finds the destination struct field by the Path given and set
-// that field to be the value of that of the one provided.
+// a configuration value to Fido. It finds the destination struct field by the Path given and
+// stages that field to be set to the value provided, leaving the actual write to fetch's commit
+// once every value from this provider invocation has been staged. A value is run through the
+// configured SecretResolvers first; a resolver error is published as a FieldUpdateError and the
+// value left unset rather than aborting the fetch, so one bad secret reference does not fail every
+// field. Once past the priority check, it is run through the field's transform= names next, in the
+// same fashion, before being staged.
 func (f *Fido) callback(provider Provider, updates chan<- *FieldUpdate) Callback {
 	return Callback(func(path Path, value interface{}) error {
+		resolved, err := resolveSecret(f.options.SecretResolvers, value)
+		if err != nil {
+			f.publish(&FieldUpdateError{
+				Err: fmt.Errorf("%w: failed to resolve secret for %s", err, path),
+			})
+
+			return nil
+		}
+
+		value = resolved
+
 		for {
 			field, ok := f.fields.get(path)
 			if !ok {
@@ -400,15 +1025,24 @@ func (f *Fido) callback(provider Provider, updates chan<- *FieldUpdate) Callback
 
 			current := field.Value().Interface()
 
-			if value != current {
+			if !reflect.DeepEqual(value, current) {
 				if field.Provider() != nil && f.options.EnforcePriority {
 					if f.providers[field.Provider()] > f.providers[provider] {
 						return nil
 					}
 				}
 
-				if err := field.Set(value, provider); err != nil {
-					return fmt.Errorf("%w: failed to set field %s value %v", err, path, value)
+				transformed, err := f.transformValue(field, path, value)
+				if err != nil {
+					f.publish(&FieldUpdateError{Err: err})
+
+					return nil
+				}
+
+				value = transformed
+
+				if err := field.Stage(value, provider); err != nil {
+					return fmt.Errorf("%w: failed to stage field %s value %v", err, path, redactIfSecret(field, value))
 				}
 
 				updates <- &FieldUpdate{
@@ -416,6 +1050,7 @@ func (f *Fido) callback(provider Provider, updates chan<- *FieldUpdate) Callback
 					New:      value,
 					Old:      current,
 					Provider: provider,
+					Secret:   isSecretField(field),
 				}
 			}
 
@@ -434,8 +1069,10 @@ func (f *Fido) initMapField(path Path, fld Field) error {
 
 	f.fields.set(path, &mapfield{
 		field: &field{
-			path:  path,
-			value: reflect.New(mv.Type().Elem()).Elem(),
+			path:   path,
+			value:  reflect.New(mv.Type().Elem()).Elem(),
+			hooks:  f.options.DecodeHooks,
+			policy: f.options.MergePolicy,
 		},
 		dst: mv,
 		idx: reflect.ValueOf(mp[len(mp)-1]),
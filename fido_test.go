@@ -1,17 +1,217 @@
 package fido
 
-import "testing"
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
 
-func TestFizz(t *testing.T) {
-	t.Parallel()
+func Test_Fido_commit(t *testing.T) {
+	p := NewTestProvider(t)
 
-	type tc struct {
-		want string
+	t.Run("CommitsEveryStagedField", func(t *testing.T) {
+		t.Parallel()
+
+		a := reflect.New(reflect.TypeOf(0)).Elem()
+		b := reflect.New(reflect.TypeOf("")).Elem()
+
+		fa := &field{path: Path{"a"}, value: a}
+		fb := &field{path: Path{"b"}, value: b}
+
+		if err := fa.Stage(1, p); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if err := fb.Stage("x", p); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		f := &Fido{fields: fields{}}
+		f.fields.set(Path{"a"}, fa)
+		f.fields.set(Path{"b"}, fb)
+
+		updates := FieldUpdates{
+			{Path: Path{"a"}, New: 1, Old: 0, Provider: p},
+			{Path: Path{"b"}, New: "x", Old: "", Provider: p},
+		}
+
+		if err := f.commit(updates); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if want := 1; !reflect.DeepEqual(want, a.Interface()) {
+			t.Errorf("want %+v, got %+v", want, a.Interface())
+		}
+
+		if want := "x"; !reflect.DeepEqual(want, b.Interface()) {
+			t.Errorf("want %+v, got %+v", want, b.Interface())
+		}
+	})
+
+	t.Run("RevertsEarlierFieldsWhenValidationFails", func(t *testing.T) {
+		t.Parallel()
+
+		a := reflect.New(reflect.TypeOf(0)).Elem()
+
+		b := reflect.New(reflect.TypeOf(0)).Elem()
+		b.SetInt(5)
+
+		fa := &field{path: Path{"a"}, value: a}
+		fb := &field{path: Path{"b"}, value: b, validates: []string{"min=10"}}
+
+		if err := fa.Stage(1, p); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if err := fb.Stage(3, p); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		f := &Fido{fields: fields{}}
+		f.fields.set(Path{"a"}, fa)
+		f.fields.set(Path{"b"}, fb)
+
+		updates := FieldUpdates{
+			{Path: Path{"a"}, New: 1, Old: 0, Provider: p},
+			{Path: Path{"b"}, New: 3, Old: 5, Provider: p},
+		}
+
+		err := f.commit(updates)
+		if !errors.Is(err, ErrValidation) {
+			t.Fatalf("want ErrValidation, got %+v", err)
+		}
+
+		if want := 0; !reflect.DeepEqual(want, a.Interface()) {
+			t.Errorf("want field a reverted to %+v, got %+v", want, a.Interface())
+		}
+
+		if want := 5; !reflect.DeepEqual(want, b.Interface()) {
+			t.Errorf("want field b left at %+v, got %+v", want, b.Interface())
+		}
+	})
+
+	t.Run("RevertsAMapFieldCommittedEarlierInTheBatch", func(t *testing.T) {
+		t.Parallel()
+
+		dst := map[string]int{"foo": 1}
+
+		fa := &mapfield{
+			field: &field{path: Path{"a", "foo"}, value: reflect.New(reflect.TypeOf(0)).Elem()},
+			dst:   reflect.ValueOf(&dst).Elem(),
+			idx:   reflect.ValueOf("foo"),
+		}
+
+		b := reflect.New(reflect.TypeOf(0)).Elem()
+		b.SetInt(5)
+
+		fb := &field{path: Path{"b"}, value: b, validates: []string{"min=10"}}
+
+		if err := fa.Stage(2, p); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if err := fb.Stage(3, p); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		f := &Fido{fields: fields{}}
+		f.fields.set(Path{"a", "foo"}, fa)
+		f.fields.set(Path{"b"}, fb)
+
+		updates := FieldUpdates{
+			{Path: Path{"a", "foo"}, New: 2, Old: 1, Provider: p},
+			{Path: Path{"b"}, New: 3, Old: 5, Provider: p},
+		}
+
+		err := f.commit(updates)
+		if !errors.Is(err, ErrValidation) {
+			t.Fatalf("want ErrValidation, got %+v", err)
+		}
+
+		if want := 1; !reflect.DeepEqual(want, dst["foo"]) {
+			t.Errorf("want map field reverted to %+v, got %+v", want, dst["foo"])
+		}
+	})
+}
+
+func Test_Fido_Add_PathProvider(t *testing.T) {
+	type dst struct {
+		Host string `fido:"host,required"`
+		Port int    `fido:"port,default=8080"`
+	}
+
+	f, err := New(&dst{})
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	pp := NewTestPathProvider(t)
+
+	f.Add(pp)
+
+	want := map[string]KnownPath{
+		"host": {Path: Path{"host"}, Required: true},
+		"port": {Path: Path{"port"}, Default: "8080", HasDefault: true},
+	}
+
+	got := pp.Received()
+	if len(got) != len(want) {
+		t.Fatalf("want %d known paths, got %d: %+v", len(want), len(got), got)
+	}
+
+	for _, kp := range got {
+		w, ok := want[kp.Path.String()]
+		if !ok {
+			t.Errorf("unexpected known path %+v", kp)
+			continue
+		}
+
+		if !reflect.DeepEqual(w, kp) {
+			t.Errorf("want %+v, got %+v", w, kp)
+		}
+	}
+
+	f.Add(pp)
+
+	if got := len(pp.Received()); got != len(want) {
+		t.Errorf("want re-adding the same provider not to push paths again, got %d received", got)
+	}
+}
+
+func Test_Fido_discard(t *testing.T) {
+	v := reflect.New(reflect.TypeOf(0)).Elem()
+	fa := &field{path: Path{"a"}, value: v}
+
+	if err := fa.Stage(123, NewTestProvider(t)); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
 	}
 
-	cases := map[string]tc{
-		"ReturnsBuzz": {
-			want: "buzz",
+	f := &Fido{fields: fields{}}
+	f.fields.set(Path{"a"}, fa)
+
+	f.discard(FieldUpdates{{Path: Path{"a"}}})
+
+	if err := fa.Commit(); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	if want := 0; !reflect.DeepEqual(want, v.Interface()) {
+		t.Errorf("want discarded Stage to never be committed, got %+v", v.Interface())
+	}
+}
+
+func Test_FieldUpdate_String(t *testing.T) {
+	cases := map[string]struct {
+		update *FieldUpdate
+		want   string
+	}{
+		"RendersOldAndNew": {
+			update: &FieldUpdate{Path: Path{"foo"}, Old: "a", New: "b"},
+			want:   "foo: a -> b",
+		},
+		"RedactsSecret": {
+			update: &FieldUpdate{Path: Path{"password"}, Old: "hunter1", New: "hunter2", Secret: true},
+			want:   "password: *** -> ***",
 		},
 	}
 
@@ -21,10 +221,8 @@ func TestFizz(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			got := Fizz()
-
-			if got != tc.want {
-				t.Errorf("want %s, got %s", tc.want, got)
+			if got := tc.update.String(); got != tc.want {
+				t.Errorf("want %q, got %q", tc.want, got)
 			}
 		})
 	}
@@ -1,14 +1,21 @@
 package fido
 
 import (
+	"encoding"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const pathSeparator = "."
 
+// durationType is compared against dst.Type() in setValue to detect a time.Duration destination,
+// whose underlying Kind is otherwise indistinguishable from a plain int64 field.
+var durationType = reflect.TypeOf(time.Duration(0))
+
 // A Field can be set to a value by a provider.
 type Field interface {
 	fmt.Stringer
@@ -16,7 +23,28 @@ type Field interface {
 	Path() Path
 	Value() reflect.Value
 	Provider() Provider
+	History() []Provider
 	Set(interface{}, Provider) error
+
+	// Stage, Commit and Rollback let a caller apply a batch of fields atomically: Stage records
+	// what Commit would write without touching Value() yet, Commit writes it, and Rollback
+	// discards it, so a caller can undo every field staged by a failed provider before any of
+	// them are written. fetch uses this to stage a whole provider invocation before committing it.
+	Stage(interface{}, Provider) error
+	Commit() error
+	Rollback()
+
+	// Revert writes to straight to the field's value, undoing an earlier Commit, the way commit's
+	// failure path does to roll back a batch already partly applied. mapfield overrides this to
+	// also write the reverted value back into its destination map, the same way Commit does.
+	Revert(to interface{}) error
+}
+
+// A Decoder is an optional extension interface a destination type can implement to take full
+// control of how a value given by a Provider is decoded into it, in preference to reflection based
+// coercion or the standard library encoding.TextUnmarshaler/encoding.BinaryUnmarshaler interfaces.
+type Decoder interface {
+	Decode(interface{}) error
 }
 
 // Path is a path to a field, e.g [foo.bar.baz] = fizz.
@@ -44,6 +72,30 @@ func (p Path) equal(other Path) bool {
 	return true
 }
 
+// isSecretField reports whether fld carries the secret tag option, consulted wherever Fido
+// constructs a FieldUpdate so its Secret flag can be set.
+func isSecretField(fld Field) bool {
+	tf, ok := fld.(taggedField)
+
+	return ok && tf.secretField()
+}
+
+// redactIfSecret returns redacted in place of value when fld carries the secret tag option,
+// consulted by error messages that would otherwise interpolate a field's raw value verbatim.
+func redactIfSecret(fld Field, value interface{}) interface{} {
+	return redactIfSecretValue(isSecretField(fld), value)
+}
+
+// redactIfSecretValue returns redacted in place of value when secret is true. Shared by
+// redactIfSecret and field's own Stage, which already has its secret flag to hand.
+func redactIfSecretValue(secret bool, value interface{}) interface{} {
+	if secret {
+		return redacted
+	}
+
+	return value
+}
+
 type fields map[string]Field
 
 func (f fields) set(path Path, field Field) {
@@ -73,9 +125,33 @@ type field struct {
 	path     Path
 	value    reflect.Value
 	provider Provider
+	history  []Provider
+	hooks    []DecodeHookFunc
+	policy   MergePolicy
+	unique   bool
+
+	required   bool
+	def        string
+	hasDefault bool
+	validates  []string
+	env        string
+	transforms []string
+	secret     bool
+	// separator overrides pathSeparator when String joins path for this field alone, set by the
+	// sep=<char> tag option. Empty means the default "." applies.
+	separator string
+
+	// pendingCommit, set by Stage, applies the staged value to value when Commit is called; a nil
+	// pendingCommit means nothing is staged, making Commit and Rollback no-ops.
+	pendingCommit func() error
+	pendingBy     Provider
 }
 
 func (f *field) String() string {
+	if f.separator != "" {
+		return strings.Join(f.path, f.separator)
+	}
+
 	return f.path.key()
 }
 
@@ -91,16 +167,173 @@ func (f *field) Provider() Provider {
 	return f.provider
 }
 
+// History returns the Provider that set this field's value, in the order each one set it, letting
+// callers audit precedence when more than one Provider set the same field.
+func (f *field) History() []Provider {
+	return f.history
+}
+
+// requiredField, defaultValue and validateRules implement the unexported taggedField interface,
+// exposing the tag options relevant to the post-Fetch validation pass in validate.go. mapfield
+// promotes these through its embedded *field.
+func (f *field) requiredField() bool {
+	return f.required
+}
+
+func (f *field) defaultValue() (string, bool) {
+	return f.def, f.hasDefault
+}
+
+func (f *field) validateRules() []string {
+	return f.validates
+}
+
+// envHint returns the env=<NAME> tag option, if any, consulted by Fido's built-in env provider in
+// preference to any path based name mapping.
+func (f *field) envHint() (string, bool) {
+	return f.env, f.env != ""
+}
+
+// transformNames returns the transform=<name> tag options, in order, consulted by the transform
+// pipeline against the Transforms registered with WithTransform.
+func (f *field) transformNames() []string {
+	return f.transforms
+}
+
+// secretField reports whether the secret tag option was given, consulted wherever Fido would
+// otherwise surface a field's value verbatim, e.g. FieldUpdate.String, so it can be redacted.
+func (f *field) secretField() bool {
+	return f.secret
+}
+
+// Set stages to and immediately commits it, equivalent to calling Stage followed by Commit. Most
+// callers that apply a single value in isolation want this; Stage and Commit exist separately for
+// callers, such as fetch, that need to apply a whole batch of fields atomically.
 func (f *field) Set(to interface{}, p Provider) error {
-	if err := setValue(f.value, to); err != nil {
-		return fmt.Errorf("%w: cannot set %s to %+v", err, f, to)
+	if err := f.Stage(to, p); err != nil {
+		return err
+	}
+
+	return f.Commit()
+}
+
+// Stage runs to through the field's decode hooks and resolves it against the field's merge policy
+// into a pendingCommit closure, ready for Commit to apply to value, without mutating value itself.
+// A provider that repeatedly calls Stage for the same field before it's committed simply replaces
+// the previous pendingCommit; only the last value given to Stage is written by Commit.
+func (f *field) Stage(to interface{}, p Provider) error {
+	for _, hook := range f.hooks {
+		v, ok, err := hook(reflect.TypeOf(to), f.value.Type(), to)
+		if err != nil {
+			return fmt.Errorf("%w: decode hook failed for %s to %+v", err, f, to)
+		}
+
+		if ok {
+			to = v
+
+			break
+		}
+	}
+
+	first := f.provider == nil
+	appendMode := !first && f.value.Kind() == reflect.Slice && f.policy&MergeAppendSlice != 0
+	deepMode := !first && f.value.Kind() == reflect.Map && f.policy&MergeDeepMap != 0
+
+	switch {
+	case !first && !appendMode && !deepMode && f.policy&MergeKeepFirst != 0:
+		f.Rollback()
+
+		return nil
+	case appendMode:
+		merged, err := f.appendMerge(to)
+		if err != nil {
+			return fmt.Errorf("%w: cannot merge %s to %+v", err, f, redactIfSecretValue(f.secret, to))
+		}
+
+		f.pendingCommit = func() error {
+			if !f.value.CanSet() {
+				return ErrReflectValueNotSetable
+			}
+
+			f.value.Set(merged)
+
+			return nil
+		}
+	case deepMode:
+		f.pendingCommit = func() error {
+			if err := deepMergeMap(f.value, to); err != nil {
+				return fmt.Errorf("%w: cannot merge %s to %+v", err, f, redactIfSecretValue(f.secret, to))
+			}
+
+			return nil
+		}
+	default:
+		f.pendingCommit = func() error {
+			if err := setValue(f.value, to); err != nil {
+				return fmt.Errorf("%w: cannot set %s to %+v", err, f, redactIfSecretValue(f.secret, to))
+			}
+
+			return nil
+		}
+	}
+
+	f.pendingBy = p
+
+	return nil
+}
+
+// Commit applies the value staged by the most recent Stage call to value and records p as the
+// Provider that set it, then clears the staged state. It is a no-op if nothing is staged, which is
+// how Stage represents a MergeKeepFirst write that should be silently dropped.
+func (f *field) Commit() error {
+	if f.pendingCommit == nil {
+		return nil
+	}
+
+	commit, by := f.pendingCommit, f.pendingBy
+
+	f.Rollback()
+
+	if err := commit(); err != nil {
+		return err
 	}
 
-	f.provider = p
+	f.provider = by
+	f.history = append(f.history, by)
 
 	return nil
 }
 
+// Rollback discards the value staged by the most recent Stage call without writing it to value.
+func (f *field) Rollback() {
+	f.pendingCommit = nil
+	f.pendingBy = nil
+}
+
+// Revert writes to straight to value, undoing an earlier Commit.
+func (f *field) Revert(to interface{}) error {
+	return setValue(f.value, to)
+}
+
+// appendMerge coerces to into f.value's slice element type and returns the result of appending it
+// to f.value's current elements, rather than replacing them, optionally deduplicating the result
+// when f.unique is set. It does not mutate f.value; Stage uses it to compute, ahead of Commit, what
+// an append merge would write.
+func (f *field) appendMerge(to interface{}) (reflect.Value, error) {
+	coerced, err := coerceSlice(f.value, to)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	merged := reflect.AppendSlice(reflect.ValueOf(f.value.Interface()), coerced)
+
+	if f.unique {
+		merged = dedupeSlice(merged)
+	}
+
+	return merged, nil
+}
+
 type mapfield struct {
 	*field
 
@@ -118,10 +351,46 @@ func (f *mapfield) Set(to interface{}, by Provider) error {
 	return nil
 }
 
+// Commit applies the embedded *field's staged value the same way Set does, then writes the result
+// into the destination map, since Commit's write to value alone would otherwise not be reflected
+// back into dst.
+func (f *mapfield) Commit() error {
+	if err := f.field.Commit(); err != nil {
+		return err
+	}
+
+	f.dst.SetMapIndex(f.idx, f.value)
+
+	return nil
+}
+
+// Revert applies the embedded *field's Revert the same way Set does, then writes the result into
+// the destination map, since Revert's write to value alone would otherwise not be reflected back
+// into dst.
+func (f *mapfield) Revert(to interface{}) error {
+	if err := f.field.Revert(to); err != nil {
+		return err
+	}
+
+	f.dst.SetMapIndex(f.idx, f.value)
+
+	return nil
+}
+
 func setValue(dst reflect.Value, to interface{}) error {
+	if handled, err := setValueViaDecoder(dst, to); handled {
+		return err
+	}
+
+	if dst.Type() == durationType {
+		return setValueToDuration(dst, to)
+	}
+
 	switch dst.Kind() {
 	case reflect.Ptr:
 		return setValue(dst.Elem(), to)
+	case reflect.Bool:
+		return setValueToBool(dst, to)
 	case reflect.String:
 		return setValueToString(dst, to)
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -132,11 +401,133 @@ func setValue(dst reflect.Value, to interface{}) error {
 		return setValueToFloat(dst, to)
 	case reflect.Slice, reflect.Array:
 		return setValueToSlice(dst, to)
+	case reflect.Map:
+		return setValueToMap(dst, to)
+	case reflect.Struct:
+		return setValueToStruct(dst, to)
+	case reflect.Interface:
+		return setValueToInterface(dst, to)
 	default:
 		return fmt.Errorf("%w: could not set %s to %s", ErrSetInvalidType, to, dst.Kind())
 	}
 }
 
+// setValueViaDecoder checks whether dst, or its addressable pointer, implements Decoder,
+// encoding.TextUnmarshaler, json.Unmarshaler or encoding.BinaryUnmarshaler, dispatching to
+// whichever is found in that order of preference instead of the reflection based Kind switch in
+// setValue. The returned bool reports whether one of these interfaces handled the value at all.
+func setValueViaDecoder(dst reflect.Value, to interface{}) (bool, error) {
+	target := dst
+
+	if target.Kind() != reflect.Ptr && target.CanAddr() {
+		target = target.Addr()
+	}
+
+	if !target.CanInterface() {
+		return false, nil
+	}
+
+	switch d := target.Interface().(type) {
+	case Decoder:
+		return true, d.Decode(to)
+	case encoding.TextUnmarshaler:
+		b, ok := toTextBytes(to)
+		if !ok {
+			return false, nil
+		}
+
+		return true, d.UnmarshalText(b)
+	case json.Unmarshaler:
+		b, ok := toJSONBytes(to)
+		if !ok {
+			return false, nil
+		}
+
+		return true, d.UnmarshalJSON(b)
+	case encoding.BinaryUnmarshaler:
+		b, ok := to.([]byte)
+		if !ok {
+			return false, nil
+		}
+
+		return true, d.UnmarshalBinary(b)
+	}
+
+	return false, nil
+}
+
+// toTextBytes coerces to into the []byte form expected by encoding.TextUnmarshaler.
+func toTextBytes(to interface{}) ([]byte, bool) {
+	switch t := to.(type) {
+	case string:
+		return []byte(t), true
+	case []byte:
+		return t, true
+	case fmt.Stringer:
+		return []byte(t.String()), true
+	default:
+		return nil, false
+	}
+}
+
+// toJSONBytes coerces to into the []byte form expected by json.Unmarshaler. A string or []byte is
+// passed through as-is, taken to already be JSON text, e.g. a raw object a provider read verbatim
+// from its source; any other value is re-encoded via json.Marshal first, e.g. a
+// map[string]interface{} a JSON or YAML provider has already decoded.
+func toJSONBytes(to interface{}) ([]byte, bool) {
+	switch t := to.(type) {
+	case string:
+		return []byte(t), true
+	case []byte:
+		return t, true
+	default:
+		b, err := json.Marshal(to)
+		if err != nil {
+			return nil, false
+		}
+
+		return b, true
+	}
+}
+
+// setValueToInterface assigns to directly to dst, used for interface{} typed destinations such as
+// the value type of a map[string]interface{} field, where there is no further type to coerce to.
+func setValueToInterface(dst reflect.Value, to interface{}) error {
+	if !dst.CanSet() {
+		return ErrReflectValueNotSetable
+	}
+
+	dst.Set(reflect.ValueOf(to))
+
+	return nil
+}
+
+func setValueToBool(dst reflect.Value, to interface{}) error {
+	if !dst.CanSet() {
+		return ErrReflectValueNotSetable
+	}
+
+	var b bool
+
+	switch t := to.(type) {
+	case string:
+		v, err := strconv.ParseBool(t)
+		if err != nil {
+			return fmt.Errorf("%w: could not convert %s to bool", ErrSetInvalidValue, t)
+		}
+
+		b = v
+	case bool:
+		b = t
+	default:
+		return fmt.Errorf("%w: cannot set %T to %s", ErrSetInvalidType, to, dst.Kind())
+	}
+
+	dst.SetBool(b)
+
+	return nil
+}
+
 func setValueToString(dst reflect.Value, to interface{}) error {
 	if !dst.CanSet() {
 		return ErrReflectValueNotSetable
@@ -221,6 +612,45 @@ func setValueToInt(dst reflect.Value, to interface{}) error {
 	return nil
 }
 
+// setValueToDuration sets dst, a time.Duration field, from either a string parsed via
+// time.ParseDuration (e.g. "5s", "1h30m") or an integer giving the duration directly in
+// nanoseconds, letting providers emit whichever is natural for their source.
+func setValueToDuration(dst reflect.Value, to interface{}) error {
+	if !dst.CanSet() {
+		return ErrReflectValueNotSetable
+	}
+
+	var d time.Duration
+
+	switch t := to.(type) {
+	case string:
+		v, err := time.ParseDuration(t)
+		if err != nil {
+			return fmt.Errorf("%w: could not parse %s as a time.Duration", ErrSetInvalidValue, t)
+		}
+
+		d = v
+	case time.Duration:
+		d = t
+	case int:
+		d = time.Duration(t)
+	case int8:
+		d = time.Duration(t)
+	case int16:
+		d = time.Duration(t)
+	case int32:
+		d = time.Duration(t)
+	case int64:
+		d = time.Duration(t)
+	default:
+		return fmt.Errorf("%w: cannot set %T to time.Duration", ErrSetInvalidType, to)
+	}
+
+	dst.SetInt(int64(d))
+
+	return nil
+}
+
 func setValueToUint(dst reflect.Value, to interface{}) error {
 	if !dst.CanSet() {
 		return ErrReflectValueNotSetable
@@ -296,11 +726,25 @@ func setValueToSlice(dst reflect.Value, to interface{}) error {
 		return ErrReflectValueNotSetable
 	}
 
+	slice, err := coerceSlice(dst, to)
+	if err != nil {
+		return err
+	}
+
+	dst.Set(slice)
+
+	return nil
+}
+
+// coerceSlice builds a new slice of dst's element type from to, setting each element via setValue,
+// without assigning the result to dst. Shared by setValueToSlice and the MergeAppendSlice path in
+// field.Set, which appends the result to dst's current value rather than replacing it.
+func coerceSlice(dst reflect.Value, to interface{}) (reflect.Value, error) {
 	dt := dst.Type()
 	tv := reflect.ValueOf(to)
 
 	if tv.Kind() != reflect.Array && tv.Kind() != reflect.Slice {
-		return fmt.Errorf("%w: expected array or slice, got %T", ErrSetInvalidType, to)
+		return reflect.Value{}, fmt.Errorf("%w: expected array or slice, got %T", ErrSetInvalidType, to)
 	}
 
 	slice := reflect.MakeSlice(reflect.SliceOf(dt.Elem()), tv.Len(), tv.Cap())
@@ -308,13 +752,81 @@ func setValueToSlice(dst reflect.Value, to interface{}) error {
 	for i := 0; i < tv.Len(); i++ {
 		e := reflect.New(dt.Elem())
 		if err := setValue(e, tv.Index(i).Interface()); err != nil {
-			return err
+			return reflect.Value{}, err
 		}
 
 		slice.Index(i).Set(e.Elem())
 	}
 
-	dst.Set(slice)
+	return slice, nil
+}
+
+func setValueToMap(dst reflect.Value, to interface{}) error {
+	if !dst.CanSet() {
+		return ErrReflectValueNotSetable
+	}
+
+	dt := dst.Type()
+	tv := reflect.ValueOf(to)
+
+	if tv.Kind() != reflect.Map {
+		return fmt.Errorf("%w: expected map, got %T", ErrSetInvalidType, to)
+	}
+
+	m := reflect.MakeMapWithSize(dt, tv.Len())
+
+	iter := tv.MapRange()
+	for iter.Next() {
+		k := reflect.New(dt.Key())
+		if err := setValue(k, iter.Key().Interface()); err != nil {
+			return err
+		}
+
+		v := reflect.New(dt.Elem())
+		if err := setValue(v, iter.Value().Interface()); err != nil {
+			return err
+		}
+
+		m.SetMapIndex(k.Elem(), v.Elem())
+	}
+
+	dst.Set(m)
+
+	return nil
+}
+
+// setValueToStruct treats to as a map[string]interface{} and dispatches each entry into the
+// matching destination field by its struct tag, mirroring how Fido.hydrate walks the destination
+// struct.
+func setValueToStruct(dst reflect.Value, to interface{}) error {
+	if !dst.CanSet() {
+		return ErrReflectValueNotSetable
+	}
+
+	src, ok := to.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%w: expected map[string]interface{}, got %T", ErrSetInvalidType, to)
+	}
+
+	dt := dst.Type()
+
+	for i := 0; i < dt.NumField(); i++ {
+		ft := dt.Field(i)
+
+		tag, err := LookupTag(DefaultStructTag, ft)
+		if err != nil {
+			continue
+		}
+
+		v, ok := src[tag.Name]
+		if !ok {
+			continue
+		}
+
+		if err := setValue(dst.Field(i), v); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
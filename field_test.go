@@ -1,9 +1,11 @@
 package fido
 
 import (
+	"encoding/json"
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestPath_equal(t *testing.T) {
@@ -200,7 +202,150 @@ func Test_mapfield_Set(t *testing.T) {
 	}
 }
 
+func Test_field_Stage_Commit(t *testing.T) {
+	f := &field{value: reflect.New(reflect.TypeOf(0)).Elem()}
+
+	p := NewTestProvider(t)
+
+	if err := f.Stage(123, p); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	if want := 0; !reflect.DeepEqual(want, f.value.Interface()) {
+		t.Errorf("want Stage to leave value unset, got %+v", f.value.Interface())
+	}
+
+	if err := f.Commit(); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	if want := 123; !reflect.DeepEqual(want, f.value.Interface()) {
+		t.Errorf("want %+v, got %+v", want, f.value.Interface())
+	}
+
+	if want := []Provider{p}; !reflect.DeepEqual(want, f.History()) {
+		t.Errorf("want %+v, got %+v", want, f.History())
+	}
+}
+
+func Test_field_Stage_Rollback(t *testing.T) {
+	f := &field{value: reflect.New(reflect.TypeOf(0)).Elem()}
+
+	if err := f.Stage(123, NewTestProvider(t)); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	f.Rollback()
+
+	if err := f.Commit(); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	if want := 0; !reflect.DeepEqual(want, f.value.Interface()) {
+		t.Errorf("want rolled back Stage to never be committed, got %+v", f.value.Interface())
+	}
+
+	if want := 0; want != len(f.History()) {
+		t.Errorf("want no history, got %+v", f.History())
+	}
+}
+
+func Test_field_Commit_NothingStagedIsNoop(t *testing.T) {
+	f := &field{value: reflect.New(reflect.TypeOf(0)).Elem()}
+
+	if err := f.Commit(); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	if want := 0; want != len(f.History()) {
+		t.Errorf("want no history, got %+v", f.History())
+	}
+}
+
+func Test_mapfield_Stage_Commit(t *testing.T) {
+	var dst = make(map[string]int)
+
+	f := &mapfield{
+		field: &field{value: reflect.New(reflect.TypeOf(0)).Elem()},
+		dst:   reflect.ValueOf(&dst).Elem(),
+		idx:   reflect.ValueOf("foo"),
+	}
+
+	if err := f.Stage(123, NewTestProvider(t)); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	if want := 0; !reflect.DeepEqual(want, dst["foo"]) {
+		t.Errorf("want Stage to leave dst unset, got %+v", dst["foo"])
+	}
+
+	if err := f.Commit(); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	if want := 123; !reflect.DeepEqual(want, dst["foo"]) {
+		t.Errorf("want %+v, got %+v", want, dst["foo"])
+	}
+}
+
+func Test_field_Revert(t *testing.T) {
+	f := &field{value: reflect.New(reflect.TypeOf(0)).Elem()}
+
+	if err := f.Stage(123, NewTestProvider(t)); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	if err := f.Commit(); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	if err := f.Revert(0); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	if want := 0; !reflect.DeepEqual(want, f.value.Interface()) {
+		t.Errorf("want %+v, got %+v", want, f.value.Interface())
+	}
+}
+
+func Test_mapfield_Revert(t *testing.T) {
+	var dst = make(map[string]int)
+
+	f := &mapfield{
+		field: &field{value: reflect.New(reflect.TypeOf(0)).Elem()},
+		dst:   reflect.ValueOf(&dst).Elem(),
+		idx:   reflect.ValueOf("foo"),
+	}
+
+	if err := f.Stage(123, NewTestProvider(t)); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	if err := f.Commit(); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	if want := 123; !reflect.DeepEqual(want, dst["foo"]) {
+		t.Errorf("want %+v, got %+v", want, dst["foo"])
+	}
+
+	if err := f.Revert(0); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	if want := 0; !reflect.DeepEqual(want, dst["foo"]) {
+		t.Errorf("want Revert to also write back to dst, got %+v", dst["foo"])
+	}
+}
+
+type structDst struct {
+	Foo string `fido:"foo"`
+	Bar int    `fido:"bar"`
+}
+
 func Test_setValue(t *testing.T) {
+	invalidKind := make(chan int)
+
 	cases := map[string]struct {
 		to   interface{}
 		dst  reflect.Value
@@ -208,9 +353,36 @@ func Test_setValue(t *testing.T) {
 		err  error
 	}{
 		"ErrSetInvalidType": {
-			dst:  reflect.ValueOf(map[string]string{}),
+			dst:  reflect.ValueOf(invalidKind),
 			err:  ErrSetInvalidType,
-			want: map[string]string{},
+			want: invalidKind,
+		},
+		"Bool": {
+			dst: func() reflect.Value {
+				var v bool
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			to:   true,
+			want: true,
+		},
+		"Map": {
+			dst: func() reflect.Value {
+				var v map[string]string
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			to:   map[string]interface{}{"foo": "bar"},
+			want: map[string]string{"foo": "bar"},
+		},
+		"Struct": {
+			dst: func() reflect.Value {
+				var v structDst
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			to:   map[string]interface{}{"foo": "bar", "bar": 123},
+			want: structDst{Foo: "bar", Bar: 123},
 		},
 		"Ptr": {
 			dst: func() reflect.Value {
@@ -266,6 +438,33 @@ func Test_setValue(t *testing.T) {
 			to:   []string{"foo", "bar"},
 			want: []string{"foo", "bar"},
 		},
+		"Interface": {
+			dst: func() reflect.Value {
+				var v interface{}
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			to:   123,
+			want: 123,
+		},
+		"Duration": {
+			dst: func() reflect.Value {
+				var v time.Duration
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			to:   "1h",
+			want: time.Hour,
+		},
+		"Time": {
+			dst: func() reflect.Value {
+				var v time.Time
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			to:   "2021-01-02T15:04:05Z",
+			want: time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC),
+		},
 	}
 
 	for name, testCase := range cases {
@@ -577,6 +776,88 @@ func Test_setValueToInt(t *testing.T) {
 	}
 }
 
+func Test_setValueToDuration(t *testing.T) {
+	cases := map[string]struct {
+		to   interface{}
+		dst  reflect.Value
+		want time.Duration
+		err  error
+	}{
+		"NotSetable": {
+			to: "5s",
+			dst: func() reflect.Value {
+				var v time.Duration
+
+				return reflect.ValueOf(v)
+			}(),
+			err: ErrReflectValueNotSetable,
+		},
+		"InvalidType": {
+			to: []string{"foo"},
+			dst: func() reflect.Value {
+				var v time.Duration
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			err: ErrSetInvalidType,
+		},
+		"InvalidSyntax": {
+			to: "not-a-duration",
+			dst: func() reflect.Value {
+				var v time.Duration
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			err: ErrSetInvalidValue,
+		},
+		"String": {
+			to: "1h30m",
+			dst: func() reflect.Value {
+				var v time.Duration
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			want: 90 * time.Minute,
+		},
+		"Duration": {
+			to: 5 * time.Second,
+			dst: func() reflect.Value {
+				var v time.Duration
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			want: 5 * time.Second,
+		},
+		"Int64Nanoseconds": {
+			to: int64(1500),
+			dst: func() reflect.Value {
+				var v time.Duration
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			want: 1500 * time.Nanosecond,
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := setValueToDuration(tc.dst, tc.to)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("want %+v err, got %+v", tc.err, err)
+			}
+
+			if want := time.Duration(tc.dst.Int()); tc.want != want {
+				t.Errorf("want %+v duration, got %+v", tc.want, want)
+			}
+		})
+	}
+}
+
 func Test_setValueToUint(t *testing.T) {
 	cases := map[string]struct {
 		to   interface{}
@@ -850,3 +1131,440 @@ func Test_setValueToSlice(t *testing.T) {
 		})
 	}
 }
+
+func Test_setValueToBool(t *testing.T) {
+	cases := map[string]struct {
+		to   interface{}
+		dst  reflect.Value
+		want bool
+		err  error
+	}{
+		"NotSetable": {
+			to: true,
+			dst: func() reflect.Value {
+				var v bool
+
+				return reflect.ValueOf(v)
+			}(),
+			err: ErrReflectValueNotSetable,
+		},
+		"InvalidType": {
+			to: []string{"foo"},
+			dst: func() reflect.Value {
+				var v bool
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			err: ErrSetInvalidType,
+		},
+		"InvalidSyntax": {
+			to: "notabool",
+			dst: func() reflect.Value {
+				var v bool
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			err: ErrSetInvalidValue,
+		},
+		"StringTrue": {
+			to: "true",
+			dst: func() reflect.Value {
+				var v bool
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			want: true,
+		},
+		"String1": {
+			to: "1",
+			dst: func() reflect.Value {
+				var v bool
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			want: true,
+		},
+		"Bool": {
+			to: true,
+			dst: func() reflect.Value {
+				var v bool
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			want: true,
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := setValueToBool(tc.dst, tc.to)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("want %+v err, got %+v", tc.err, err)
+			}
+
+			if !reflect.DeepEqual(tc.want, tc.dst.Bool()) {
+				t.Errorf("want %+v value, got %+v", tc.want, tc.dst.Bool())
+			}
+		})
+	}
+}
+
+func Test_setValueToMap(t *testing.T) {
+	cases := map[string]struct {
+		to   interface{}
+		dst  reflect.Value
+		want interface{}
+		err  error
+	}{
+		"NotSetable": {
+			to: map[string]interface{}{"foo": "bar"},
+			dst: func() reflect.Value {
+				var v map[string]string
+
+				return reflect.ValueOf(v)
+			}(),
+			err:  ErrReflectValueNotSetable,
+			want: map[string]string(nil),
+		},
+		"InvalidType": {
+			to: "foo",
+			dst: func() reflect.Value {
+				var v map[string]string
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			err:  ErrSetInvalidType,
+			want: map[string]string(nil),
+		},
+		"InvalidValue": {
+			to: map[string]interface{}{"foo": "notanint"},
+			dst: func() reflect.Value {
+				var v map[string]int
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			err:  ErrSetInvalidValue,
+			want: map[string]int(nil),
+		},
+		"SetsValues": {
+			to: map[string]interface{}{"foo": "bar"},
+			dst: func() reflect.Value {
+				var v map[string]string
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			want: map[string]string{"foo": "bar"},
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := setValueToMap(tc.dst, tc.to)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("want %+v err, got %+v", tc.err, err)
+			}
+
+			if !reflect.DeepEqual(tc.want, tc.dst.Interface()) {
+				t.Errorf("want %+v value, got %+v", tc.want, tc.dst.Interface())
+			}
+		})
+	}
+}
+
+func Test_setValueToStruct(t *testing.T) {
+	cases := map[string]struct {
+		to   interface{}
+		dst  reflect.Value
+		want structDst
+		err  error
+	}{
+		"NotSetable": {
+			to: map[string]interface{}{"foo": "bar"},
+			dst: func() reflect.Value {
+				var v structDst
+
+				return reflect.ValueOf(v)
+			}(),
+			err: ErrReflectValueNotSetable,
+		},
+		"InvalidType": {
+			to: "foo",
+			dst: func() reflect.Value {
+				var v structDst
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			err: ErrSetInvalidType,
+		},
+		"FieldError": {
+			to: map[string]interface{}{"bar": "notanint"},
+			dst: func() reflect.Value {
+				var v structDst
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			err: ErrSetInvalidValue,
+		},
+		"IgnoresUnknownFields": {
+			to: map[string]interface{}{"fizz": "buzz"},
+			dst: func() reflect.Value {
+				var v structDst
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			want: structDst{},
+		},
+		"SetsFields": {
+			to: map[string]interface{}{"foo": "bar", "bar": 123},
+			dst: func() reflect.Value {
+				var v structDst
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			want: structDst{Foo: "bar", Bar: 123},
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := setValueToStruct(tc.dst, tc.to)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("want %+v err, got %+v", tc.err, err)
+			}
+
+			if tc.dst.CanInterface() && tc.dst.Kind() == reflect.Struct {
+				if got := tc.dst.Interface().(structDst); !reflect.DeepEqual(tc.want, got) {
+					t.Errorf("want %+v, got %+v", tc.want, got)
+				}
+			}
+		})
+	}
+}
+
+type testDecoder struct {
+	value interface{}
+}
+
+func (d *testDecoder) Decode(v interface{}) error {
+	d.value = v
+
+	return nil
+}
+
+type testTextUnmarshaler struct {
+	value string
+}
+
+func (t *testTextUnmarshaler) UnmarshalText(b []byte) error {
+	t.value = string(b)
+
+	return nil
+}
+
+type testBinaryUnmarshaler struct {
+	value []byte
+}
+
+func (t *testBinaryUnmarshaler) UnmarshalBinary(b []byte) error {
+	t.value = b
+
+	return nil
+}
+
+type testJSONUnmarshaler struct {
+	Value string `json:"value"`
+}
+
+func (t *testJSONUnmarshaler) UnmarshalJSON(b []byte) error {
+	type alias testJSONUnmarshaler
+
+	var a alias
+
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	*t = testJSONUnmarshaler(a)
+
+	return nil
+}
+
+func Test_setValueViaDecoder(t *testing.T) {
+	cases := map[string]struct {
+		to      interface{}
+		dst     reflect.Value
+		handled bool
+		want    interface{}
+	}{
+		"NotImplemented": {
+			to: "foo",
+			dst: func() reflect.Value {
+				var v int
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			handled: false,
+			want:    0,
+		},
+		"Decoder": {
+			to: "foo",
+			dst: func() reflect.Value {
+				var v testDecoder
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			handled: true,
+			want:    testDecoder{value: "foo"},
+		},
+		"TextUnmarshaler": {
+			to: "foo",
+			dst: func() reflect.Value {
+				var v testTextUnmarshaler
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			handled: true,
+			want:    testTextUnmarshaler{value: "foo"},
+		},
+		"TextUnmarshalerUnsupportedSource": {
+			to: 123,
+			dst: func() reflect.Value {
+				var v testTextUnmarshaler
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			handled: false,
+			want:    testTextUnmarshaler{},
+		},
+		"JSONUnmarshaler": {
+			to: `{"value":"foo"}`,
+			dst: func() reflect.Value {
+				var v testJSONUnmarshaler
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			handled: true,
+			want:    testJSONUnmarshaler{Value: "foo"},
+		},
+		"JSONUnmarshalerFromMap": {
+			to: map[string]interface{}{"value": "bar"},
+			dst: func() reflect.Value {
+				var v testJSONUnmarshaler
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			handled: true,
+			want:    testJSONUnmarshaler{Value: "bar"},
+		},
+		"BinaryUnmarshaler": {
+			to: []byte("foo"),
+			dst: func() reflect.Value {
+				var v testBinaryUnmarshaler
+
+				return reflect.ValueOf(&v).Elem()
+			}(),
+			handled: true,
+			want:    testBinaryUnmarshaler{value: []byte("foo")},
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			handled, err := setValueViaDecoder(tc.dst, tc.to)
+
+			if handled != tc.handled {
+				t.Errorf("want handled %+v, got %+v", tc.handled, handled)
+			}
+
+			if err != nil {
+				t.Errorf("unexpected err: %+v", err)
+			}
+
+			if !reflect.DeepEqual(tc.want, tc.dst.Interface()) {
+				t.Errorf("want %+v value, got %+v", tc.want, tc.dst.Interface())
+			}
+		})
+	}
+}
+
+var errTestHook = errors.New("test hook error")
+
+func Test_field_Set_hooks(t *testing.T) {
+	cases := map[string]struct {
+		to    interface{}
+		hooks []DecodeHookFunc
+		want  interface{}
+		err   error
+	}{
+		"HookConvertsValue": {
+			to: "123",
+			hooks: []DecodeHookFunc{
+				func(from, to reflect.Type, v interface{}) (interface{}, bool, error) {
+					return 123, true, nil
+				},
+			},
+			want: 123,
+		},
+		"HookError": {
+			to: "bad",
+			hooks: []DecodeHookFunc{
+				func(from, to reflect.Type, v interface{}) (interface{}, bool, error) {
+					return nil, false, errTestHook
+				},
+			},
+			want: 0,
+			err:  errTestHook,
+		},
+		"NoMatchFallsBackToSetValue": {
+			to: 123,
+			hooks: []DecodeHookFunc{
+				func(from, to reflect.Type, v interface{}) (interface{}, bool, error) {
+					return nil, false, nil
+				},
+			},
+			want: 123,
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			f := &field{
+				value: reflect.New(reflect.TypeOf(0)).Elem(),
+				hooks: tc.hooks,
+			}
+
+			err := f.Set(tc.to, NewTestProvider(t))
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("want %+v err, got %+v", tc.err, err)
+			}
+
+			if !reflect.DeepEqual(tc.want, f.value.Interface()) {
+				t.Errorf("want %+v, got %+v", tc.want, f.value.Interface())
+			}
+		})
+	}
+}
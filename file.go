@@ -6,11 +6,19 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
 )
 
 // FilesProviderName is the name of the FilesProvider.
 const FilesProviderName = "Files"
 
+// fileWatchDebounce coalesces the burst of fsnotify events a single logical write to a file tends
+// to produce (e.g. an editor's write-then-rename, or a Kubernetes ConfigMap symlink swap) into one
+// reload.
+const fileWatchDebounce = 100 * time.Millisecond
+
 // FromFiles returns a FileProvider wrapping the given ReadProvider. The given patterns should absolute
 // paths or globs.
 func FromFiles(provider ReadProvider, patterns ...string) *FileProvider {
@@ -24,12 +32,30 @@ func FromFiles(provider ReadProvider, patterns ...string) *FileProvider {
 	}
 }
 
+// FromFilesWatch is a convenience wrapper around FromFiles(provider, patterns...).Watch(), for
+// callers who want a watching FileProvider without chaining Watch themselves.
+func FromFilesWatch(provider ReadProvider, patterns ...string) *FileProvider {
+	return FromFiles(provider, patterns...).Watch()
+}
+
 // FileProvider provides a standard Provider that wraps a given ReadProvider.
 type FileProvider struct {
 	patterns []string
 	matches  map[string]struct{}
 	provider ReadProvider
 	open     func(string) (io.ReadCloser, error)
+	watch    bool
+}
+
+// Watch enables an opt-in mode where, once the initial Values pass has read every matched file,
+// Values keeps running rather than returning: it fsnotify-watches each resolved path and its
+// parent directory, so an atomic-replace editor or a Kubernetes ConfigMap symlink swap is picked up
+// too, and re-reads and re-emits any path that receives a Write, Create or Rename event, debounced
+// by fileWatchDebounce, until ctx is done.
+func (p *FileProvider) Watch() *FileProvider {
+	p.watch = true
+
+	return p
 }
 
 func (p *FileProvider) String() string {
@@ -37,8 +63,9 @@ func (p *FileProvider) String() string {
 }
 
 // Values searches for files matching the patterns provided, opening each file and passing them to
-// the given ReadProvider for processing.
-func (p *FileProvider) Values(ctx context.Context, callback Callback) error {
+// the given ReadProvider for processing. If Watch was called, Values does not return once the
+// initial pass completes; it instead fsnotify-watches every matched file until ctx is done.
+func (p *FileProvider) Values(ctx context.Context, writer Writer) error {
 	for _, pattern := range p.patterns {
 		select {
 		case <-ctx.Done():
@@ -54,23 +81,120 @@ func (p *FileProvider) Values(ctx context.Context, callback Callback) error {
 					continue
 				}
 
-				f, err := p.open(path)
-				if err != nil {
-					return fmt.Errorf("%w: failed to open %s", err, path)
-				}
-
 				p.matches[path] = struct{}{}
 
-				if err := p.provider.Values(ctx, f, callback); err != nil {
+				if err := p.reload(ctx, path, writer); err != nil {
 					return err
 				}
-
-				if err := f.Close(); err != nil {
-					return fmt.Errorf("%w: failed to close %s", err, path)
-				}
 			}
 		}
 	}
 
+	if !p.watch {
+		return nil
+	}
+
+	return p.watchFiles(ctx, writer)
+}
+
+// reload opens path and passes it to the wrapped ReadProvider, pushing any values it emits through
+// writer, then closes it.
+func (p *FileProvider) reload(ctx context.Context, path string, writer Writer) error {
+	f, err := p.open(path)
+	if err != nil {
+		return fmt.Errorf("%w: failed to open %s", err, path)
+	}
+
+	if err := p.provider.Values(ctx, f, writer); err != nil {
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%w: failed to close %s", err, path)
+	}
+
 	return nil
 }
+
+// watchFiles fsnotify-watches every path in p.matches, and its parent directory, calling reload
+// against a path once a Write, Create or Rename event against it settles for fileWatchDebounce
+// without another one arriving, until ctx is done. An fsnotify error is returned the same way a
+// reload error is, ending the watch.
+func (p *FileProvider) watchFiles(ctx context.Context, writer Writer) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("%w: failed to start file watcher", err)
+	}
+	defer watcher.Close()
+
+	dirs := make(map[string]struct{})
+
+	for path := range p.matches {
+		if err := watcher.Add(path); err != nil {
+			return fmt.Errorf("%w: failed to watch %s", err, path)
+		}
+
+		dir := filepath.Dir(path)
+		if _, ok := dirs[dir]; !ok {
+			if err := watcher.Add(dir); err != nil {
+				return fmt.Errorf("%w: failed to watch %s", err, dir)
+			}
+
+			dirs[dir] = struct{}{}
+		}
+	}
+
+	pending := make(map[string]*time.Timer)
+
+	defer func() {
+		for _, timer := range pending {
+			timer.Stop()
+		}
+	}()
+
+	reloads := make(chan string)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case path := <-reloads:
+			delete(pending, path)
+
+			if err := p.reload(ctx, path, writer); err != nil {
+				return err
+			}
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			if _, ok := p.matches[event.Name]; !ok {
+				continue
+			}
+
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+				continue
+			}
+
+			if timer, ok := pending[event.Name]; ok {
+				timer.Stop()
+			}
+
+			path := event.Name
+
+			pending[path] = time.AfterFunc(fileWatchDebounce, func() {
+				select {
+				case reloads <- path:
+				case <-ctx.Done():
+				}
+			})
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+
+			return fmt.Errorf("%w: file watcher error", err)
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 type ReadCloser struct {
@@ -63,7 +64,7 @@ func TestFilesProvider(t *testing.T) {
 		ctx      context.Context
 		provider ReadProvider
 		patterns []string
-		callback func(*testing.T) Callback
+		callback func(*testing.T) Writer
 		openfn   func(string) (io.ReadCloser, error)
 		err      error
 	}{
@@ -75,20 +76,20 @@ func TestFilesProvider(t *testing.T) {
 				return ctx
 			}(),
 			patterns: []string{t1.Name()},
-			callback: func(t *testing.T) Callback {
-				return func(Path, interface{}) error {
+			callback: func(t *testing.T) Writer {
+				return WriterFunc(func(Path, interface{}) error {
 					return nil
-				}
+				})
 			},
 			err: context.Canceled,
 		},
 		"BadPattern": {
 			ctx:      context.Background(),
 			patterns: []string{"[]a]"},
-			callback: func(t *testing.T) Callback {
-				return func(Path, interface{}) error {
+			callback: func(t *testing.T) Writer {
+				return WriterFunc(func(Path, interface{}) error {
 					return nil
-				}
+				})
 			},
 			err: filepath.ErrBadPattern,
 		},
@@ -100,10 +101,10 @@ func TestFilesProvider(t *testing.T) {
 			openfn: func(string) (io.ReadCloser, error) {
 				return nil, os.ErrNotExist
 			},
-			callback: func(t *testing.T) Callback {
-				return func(Path, interface{}) error {
+			callback: func(t *testing.T) Writer {
+				return WriterFunc(func(Path, interface{}) error {
 					return nil
-				}
+				})
 			},
 			err: os.ErrNotExist,
 		},
@@ -112,13 +113,13 @@ func TestFilesProvider(t *testing.T) {
 			patterns: []string{
 				t1.Name(),
 			},
-			provider: NewTestReadProvider(t, func(ctx context.Context, reader io.Reader, callback Callback) error {
+			provider: NewTestReadProvider(t, func(ctx context.Context, reader io.Reader, writer Writer) error {
 				return ErrSetInvalidValue
 			}),
-			callback: func(t *testing.T) Callback {
-				return func(Path, interface{}) error {
+			callback: func(t *testing.T) Writer {
+				return WriterFunc(func(Path, interface{}) error {
 					return nil
-				}
+				})
 			},
 			err: ErrSetInvalidValue,
 		},
@@ -134,13 +135,13 @@ func TestFilesProvider(t *testing.T) {
 					},
 				}, nil
 			},
-			provider: NewTestReadProvider(t, func(ctx context.Context, reader io.Reader, callback Callback) error {
+			provider: NewTestReadProvider(t, func(ctx context.Context, reader io.Reader, writer Writer) error {
 				return nil
 			}),
-			callback: func(t *testing.T) Callback {
-				return func(Path, interface{}) error {
+			callback: func(t *testing.T) Writer {
+				return WriterFunc(func(Path, interface{}) error {
 					return nil
-				}
+				})
 			},
 			err: os.ErrClosed,
 		},
@@ -150,7 +151,7 @@ func TestFilesProvider(t *testing.T) {
 				t1.Name(),
 				t1.Name(), // Prevents duplicate
 			},
-			provider: NewTestReadProvider(t, func(ctx context.Context, reader io.Reader, callback Callback) error {
+			provider: NewTestReadProvider(t, func(ctx context.Context, reader io.Reader, writer Writer) error {
 				b, err := ioutil.ReadAll(reader)
 				if err != nil {
 					return err
@@ -158,10 +159,10 @@ func TestFilesProvider(t *testing.T) {
 
 				parts := strings.Split(string(b), ":")
 
-				return callback(Path{parts[0]}, parts[1])
+				return writer.Write(Path{parts[0]}, parts[1])
 			}),
-			callback: func(t *testing.T) Callback {
-				return func(path Path, value interface{}) error {
+			callback: func(t *testing.T) Writer {
+				return WriterFunc(func(path Path, value interface{}) error {
 					{
 						want := Path{"foo"}
 						if !reflect.DeepEqual(path, want) {
@@ -177,7 +178,7 @@ func TestFilesProvider(t *testing.T) {
 					}
 
 					return nil
-				}
+				})
 			},
 		},
 	}
@@ -200,3 +201,96 @@ func TestFilesProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestFromFilesWatch(t *testing.T) {
+	t.Parallel()
+
+	p := FromFilesWatch(NewTestReadProvider(t, nil), "*.cfg")
+
+	if !p.watch {
+		t.Errorf("want FromFilesWatch to enable watch mode")
+	}
+}
+
+// NOTE: this test is only run for go1.14+ due to the test cleanup method
+func TestFilesProvider_Watch(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Error(err)
+		}
+	})
+
+	path := filepath.Join(dir, "t1.cfg")
+
+	if err := ioutil.WriteFile(path, []byte("foo:bar"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	values := make(chan string)
+
+	provider := NewTestReadProvider(t, func(ctx context.Context, reader io.Reader, writer Writer) error {
+		b, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+
+		parts := strings.Split(string(b), ":")
+
+		if err := writer.Write(Path{parts[0]}, parts[1]); err != nil {
+			return err
+		}
+
+		values <- parts[1]
+
+		return nil
+	})
+
+	p := FromFiles(provider, path).Watch()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errs := make(chan error, 1)
+
+	go func() {
+		errs <- p.Values(ctx, WriterFunc(func(Path, interface{}) error { return nil }))
+	}()
+
+	select {
+	case v := <-values:
+		if want := "bar"; v != want {
+			t.Fatalf("want initial value %q, got %q", want, v)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for initial value")
+	}
+
+	if err := ioutil.WriteFile(path, []byte("foo:baz"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case v := <-values:
+		if want := "baz"; v != want {
+			t.Fatalf("want reloaded value %q, got %q", want, v)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reloaded value")
+	}
+
+	cancel()
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("want %+v, got %+v", context.Canceled, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for Values to return after cancel")
+	}
+}
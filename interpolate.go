@@ -0,0 +1,156 @@
+package fido
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// interpolationEnvNamespace is the ns: prefix that routes a reference to os.Getenv instead of the
+// fields tree, e.g. ${env:DB_HOST}.
+const interpolationEnvNamespace = "env"
+
+// interpolationDefaultSep separates a reference from its fallback value, e.g. ${name:-default}.
+const interpolationDefaultSep = ":-"
+
+// interpolate expands ${...} references in every string, and []string, value held by flds. An
+// unqualified reference, e.g. ${app.home}, resolves against the field at that dotted Path. A
+// reference prefixed env:, e.g. ${env:DB_HOST}, resolves against the process environment via
+// os.Getenv. Either form may carry a :-default fallback used when the reference is unset, and $$ is
+// an escape sequence for a literal $. interpolate returns ErrInterpolationCycle if resolving a
+// reference would require resolving itself.
+func interpolate(flds fields) error {
+	cache := make(map[string]string, len(flds))
+	visiting := make(map[string]bool, len(flds))
+
+	var resolve func(path Path) (string, error)
+
+	resolve = func(path Path) (string, error) {
+		key := path.key()
+
+		if v, ok := cache[key]; ok {
+			return v, nil
+		}
+
+		if visiting[key] {
+			return "", fmt.Errorf("%w: %s", ErrInterpolationCycle, path)
+		}
+
+		fld, ok := flds[key]
+		if !ok || fld.Value().Kind() != reflect.String {
+			return "", nil
+		}
+
+		visiting[key] = true
+
+		v, err := expand(fld.Value().String(), resolve)
+		if err != nil {
+			return "", err
+		}
+
+		delete(visiting, key)
+
+		cache[key] = v
+
+		return v, nil
+	}
+
+	for _, fld := range flds {
+		value := fld.Value()
+
+		switch {
+		case value.Kind() == reflect.String:
+			v, err := expand(value.String(), resolve)
+			if err != nil {
+				return err
+			}
+
+			if value.CanSet() {
+				value.SetString(v)
+			}
+		case value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.String:
+			for i := 0; i < value.Len(); i++ {
+				elem := value.Index(i)
+
+				v, err := expand(elem.String(), resolve)
+				if err != nil {
+					return err
+				}
+
+				if elem.CanSet() {
+					elem.SetString(v)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// expand scans s for $$ and ${...} and returns the string with every reference resolved via
+// resolve.
+func expand(s string, resolve func(Path) (string, error)) (string, error) {
+	var b strings.Builder
+
+	for i := 0; i < len(s); {
+		switch {
+		case s[i] == '$' && i+1 < len(s) && s[i+1] == '$':
+			b.WriteByte('$')
+			i += 2
+		case s[i] == '$' && i+1 < len(s) && s[i+1] == '{':
+			end := strings.IndexByte(s[i+2:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("%w: unterminated ${ in %q", ErrInterpolationSyntax, s)
+			}
+
+			v, err := resolveRef(s[i+2:i+2+end], resolve)
+			if err != nil {
+				return "", err
+			}
+
+			b.WriteString(v)
+
+			i += 2 + end + 1
+		default:
+			b.WriteByte(s[i])
+			i++
+		}
+	}
+
+	return b.String(), nil
+}
+
+// resolveRef resolves a single ${...} reference body, e.g. "app.home", "env:DB_HOST" or
+// "env:DB_HOST:-localhost", against resolve or the process environment.
+func resolveRef(ref string, resolve func(Path) (string, error)) (string, error) {
+	name, def, hasDefault := ref, "", false
+
+	if i := strings.Index(ref, interpolationDefaultSep); i >= 0 {
+		name, def, hasDefault = ref[:i], ref[i+len(interpolationDefaultSep):], true
+	}
+
+	if ns := strings.SplitN(name, ":", 2); len(ns) == 2 && ns[0] == interpolationEnvNamespace {
+		v, ok := os.LookupEnv(ns[1])
+		if !ok || v == "" {
+			if hasDefault {
+				return def, nil
+			}
+
+			return "", nil
+		}
+
+		return v, nil
+	}
+
+	v, err := resolve(Path(strings.Split(name, pathSeparator)))
+	if err != nil {
+		return "", err
+	}
+
+	if v == "" && hasDefault {
+		return def, nil
+	}
+
+	return v, nil
+}
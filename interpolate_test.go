@@ -0,0 +1,112 @@
+package fido
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func stringField(path Path, v string) *field {
+	value := reflect.New(reflect.TypeOf("")).Elem()
+	value.SetString(v)
+
+	return &field{path: path, value: value}
+}
+
+func sliceField(path Path, v []string) *field {
+	value := reflect.New(reflect.TypeOf([]string{})).Elem()
+	value.Set(reflect.ValueOf(v))
+
+	return &field{path: path, value: value}
+}
+
+func Test_interpolate(t *testing.T) {
+	cases := map[string]struct {
+		flds fields
+		env  map[string]string
+		want map[string]string
+		err  error
+	}{
+		"NoReferences": {
+			flds: fields{
+				"foo": stringField(Path{"foo"}, "bar"),
+			},
+			want: map[string]string{"foo": "bar"},
+		},
+		"Escape": {
+			flds: fields{
+				"foo": stringField(Path{"foo"}, "$$1.00"),
+			},
+			want: map[string]string{"foo": "$1.00"},
+		},
+		"FieldReference": {
+			flds: fields{
+				"app.home": stringField(Path{"app", "home"}, "/srv/app"),
+				"log.dir":  stringField(Path{"log", "dir"}, "${app.home}/logs"),
+			},
+			want: map[string]string{
+				"app.home": "/srv/app",
+				"log.dir":  "/srv/app/logs",
+			},
+		},
+		"EnvReference": {
+			flds: fields{
+				"db.user": stringField(Path{"db", "user"}, "${env:DB_USER}"),
+			},
+			env:  map[string]string{"DB_USER": "admin"},
+			want: map[string]string{"db.user": "admin"},
+		},
+		"EnvReferenceDefault": {
+			flds: fields{
+				"db.host": stringField(Path{"db", "host"}, "${env:DB_HOST:-localhost}"),
+			},
+			want: map[string]string{"db.host": "localhost"},
+		},
+		"FieldReferenceDefault": {
+			flds: fields{
+				"log.dir": stringField(Path{"log", "dir"}, "${app.home:-/var/app}/logs"),
+			},
+			want: map[string]string{"log.dir": "/var/app/logs"},
+		},
+		"Slice": {
+			flds: fields{
+				"hosts": sliceField(Path{"hosts"}, []string{"${env:HOST}", "static"}),
+			},
+			env:  map[string]string{"HOST": "dynamic"},
+			want: map[string]string{"hosts": "[dynamic static]"},
+		},
+		"Cycle": {
+			flds: fields{
+				"a": stringField(Path{"a"}, "${b}"),
+				"b": stringField(Path{"b"}, "${a}"),
+			},
+			err: ErrInterpolationCycle,
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			err := interpolate(tc.flds)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("want error %+v, got %+v", tc.err, err)
+			}
+
+			for key, want := range tc.want {
+				fld := tc.flds[key]
+
+				got := fmt.Sprintf("%v", fld.Value().Interface())
+				if got != want {
+					t.Errorf("%s: want %s, got %s", key, want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package fido
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// A MergePolicy controls how a field's value is combined when more than one Provider sets it
+// during a Fetch. Policies are bit flags and may be combined, e.g.
+// MergeOverride|MergeAppendSlice|MergeDeepMap applies the override behaviour to scalar fields while
+// still appending slices and deep merging maps.
+type MergePolicy uint8
+
+const (
+	// MergeOverride replaces the current value with the value from the latest provider. This is
+	// the default behaviour.
+	MergeOverride MergePolicy = 1 << iota
+	// MergeKeepFirst keeps the value set by the first provider to set it, ignoring later writes.
+	MergeKeepFirst
+	// MergeAppendSlice appends a reflect.Slice destination's new value to its current value instead
+	// of replacing it. Combine with the unique tag option, e.g. fido:"path,merge=append,unique", to
+	// deduplicate the result.
+	MergeAppendSlice
+	// MergeDeepMap recursively merges a map[string]interface{} destination's new value into its
+	// current value instead of replacing it, rather than clobbering the whole map.
+	MergeDeepMap
+)
+
+// mergePolicyFor resolves the effective MergePolicy and unique flag for a field given its struct
+// tag and the Fido level fallback policy, letting a fido:"path,merge=..." tag override the fallback
+// for that one field.
+func mergePolicyFor(tag Tag, fallback MergePolicy) (MergePolicy, bool) {
+	switch tag.Merge {
+	case "":
+		return fallback, tag.Unique
+	case "override":
+		return MergeOverride, tag.Unique
+	case "keep-first":
+		return MergeKeepFirst, tag.Unique
+	case "append":
+		return MergeAppendSlice, tag.Unique
+	case "deep":
+		return MergeDeepMap, tag.Unique
+	default:
+		return fallback, tag.Unique
+	}
+}
+
+// dedupeSlice returns a copy of s with duplicate elements, compared with reflect.DeepEqual, removed,
+// keeping the first occurrence of each value.
+func dedupeSlice(s reflect.Value) reflect.Value {
+	deduped := reflect.MakeSlice(s.Type(), 0, s.Len())
+
+	for i := 0; i < s.Len(); i++ {
+		v := s.Index(i)
+
+		var found bool
+
+		for j := 0; j < deduped.Len(); j++ {
+			if reflect.DeepEqual(deduped.Index(j).Interface(), v.Interface()) {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			deduped = reflect.Append(deduped, v)
+		}
+	}
+
+	return deduped
+}
+
+// deepMergeMap merges to, which must be a map, into dst key by key. Where both dst and to hold a
+// map[string]interface{} value for the same key the two are merged recursively rather than to's
+// value clobbering dst's, mirroring the behaviour of libraries like mergo.
+func deepMergeMap(dst reflect.Value, to interface{}) error {
+	tv := reflect.ValueOf(to)
+	if tv.Kind() != reflect.Map {
+		return fmt.Errorf("%w: expected map, got %T", ErrSetInvalidType, to)
+	}
+
+	if dst.IsNil() {
+		return setValueToMap(dst, to)
+	}
+
+	dt := dst.Type()
+
+	iter := tv.MapRange()
+	for iter.Next() {
+		k := reflect.New(dt.Key())
+		if err := setValue(k, iter.Key().Interface()); err != nil {
+			return err
+		}
+
+		if dt.Elem().Kind() == reflect.Interface {
+			existing := dst.MapIndex(k.Elem())
+
+			if existing.IsValid() {
+				ev, eok := existing.Interface().(map[string]interface{})
+				nv, nok := iter.Value().Interface().(map[string]interface{})
+
+				if eok && nok {
+					merged := reflect.MakeMap(reflect.TypeOf(map[string]interface{}{}))
+					for _, mk := range reflect.ValueOf(ev).MapKeys() {
+						merged.SetMapIndex(mk, reflect.ValueOf(ev).MapIndex(mk))
+					}
+
+					if err := deepMergeMap(merged, nv); err != nil {
+						return err
+					}
+
+					dst.SetMapIndex(k.Elem(), merged)
+
+					continue
+				}
+			}
+		}
+
+		v := reflect.New(dt.Elem())
+		if err := setValue(v, iter.Value().Interface()); err != nil {
+			return err
+		}
+
+		dst.SetMapIndex(k.Elem(), v.Elem())
+	}
+
+	return nil
+}
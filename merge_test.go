@@ -0,0 +1,265 @@
+package fido
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_mergePolicyFor(t *testing.T) {
+	cases := map[string]struct {
+		tag      Tag
+		fallback MergePolicy
+		want     MergePolicy
+		unique   bool
+	}{
+		"NoTagUsesFallback": {
+			tag:      Tag{},
+			fallback: MergeKeepFirst,
+			want:     MergeKeepFirst,
+		},
+		"Override": {
+			tag:  Tag{Merge: "override"},
+			want: MergeOverride,
+		},
+		"KeepFirst": {
+			tag:  Tag{Merge: "keep-first"},
+			want: MergeKeepFirst,
+		},
+		"Append": {
+			tag:  Tag{Merge: "append"},
+			want: MergeAppendSlice,
+		},
+		"AppendUnique": {
+			tag:    Tag{Merge: "append", Unique: true},
+			want:   MergeAppendSlice,
+			unique: true,
+		},
+		"Deep": {
+			tag:  Tag{Merge: "deep"},
+			want: MergeDeepMap,
+		},
+		"UnknownFallsBackToFallback": {
+			tag:      Tag{Merge: "bogus"},
+			fallback: MergeOverride,
+			want:     MergeOverride,
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			policy, unique := mergePolicyFor(tc.tag, tc.fallback)
+
+			if policy != tc.want {
+				t.Errorf("want policy %v, got %v", tc.want, policy)
+			}
+
+			if unique != tc.unique {
+				t.Errorf("want unique %v, got %v", tc.unique, unique)
+			}
+		})
+	}
+}
+
+func Test_field_Set_mergePolicy(t *testing.T) {
+	cases := map[string]struct {
+		field *field
+		first interface{}
+		to    interface{}
+		want  interface{}
+	}{
+		"OverrideReplacesValue": {
+			field: &field{value: reflect.New(reflect.TypeOf("")).Elem(), policy: MergeOverride},
+			first: "a",
+			to:    "b",
+			want:  "b",
+		},
+		"KeepFirstIgnoresLaterWrites": {
+			field: &field{value: reflect.New(reflect.TypeOf("")).Elem(), policy: MergeKeepFirst},
+			first: "a",
+			to:    "b",
+			want:  "a",
+		},
+		"AppendSliceAppends": {
+			field: &field{value: reflect.New(reflect.TypeOf([]string{})).Elem(), policy: MergeAppendSlice},
+			first: []string{"a"},
+			to:    []string{"b"},
+			want:  []string{"a", "b"},
+		},
+		"AppendSliceUniqueDeduplicates": {
+			field: &field{value: reflect.New(reflect.TypeOf([]string{})).Elem(), policy: MergeAppendSlice, unique: true},
+			first: []string{"a", "b"},
+			to:    []string{"b", "c"},
+			want:  []string{"a", "b", "c"},
+		},
+		"DeepMapMergesRecursively": {
+			field: &field{value: reflect.New(reflect.TypeOf(map[string]interface{}{})).Elem(), policy: MergeDeepMap},
+			first: map[string]interface{}{"a": map[string]interface{}{"x": 1}},
+			to:    map[string]interface{}{"a": map[string]interface{}{"y": 2}, "b": 3},
+			want: map[string]interface{}{
+				"a": map[string]interface{}{"x": 1, "y": 2},
+				"b": 3,
+			},
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tc.field.Set(tc.first, NewTestProvider(t)); err != nil {
+				t.Fatalf("unexpected error on first set: %+v", err)
+			}
+
+			if err := tc.field.Set(tc.to, NewTestProvider(t)); err != nil {
+				t.Fatalf("unexpected error on second set: %+v", err)
+			}
+
+			if !reflect.DeepEqual(tc.want, tc.field.value.Interface()) {
+				t.Errorf("want %+v, got %+v", tc.want, tc.field.value.Interface())
+			}
+		})
+	}
+}
+
+func Test_field_History(t *testing.T) {
+	f := &field{value: reflect.New(reflect.TypeOf("")).Elem()}
+
+	p1 := NewTestProvider(t)
+	p2 := NewTestProvider(t)
+
+	if err := f.Set("a", p1); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	if err := f.Set("b", p2); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	want := []Provider{p1, p2}
+
+	if !reflect.DeepEqual(want, f.History()) {
+		t.Errorf("want %+v, got %+v", want, f.History())
+	}
+}
+
+func Test_dedupeSlice(t *testing.T) {
+	got := dedupeSlice(reflect.ValueOf([]string{"a", "b", "a", "c", "b"}))
+
+	want := []string{"a", "b", "c"}
+
+	if !reflect.DeepEqual(want, got.Interface()) {
+		t.Errorf("want %+v, got %+v", want, got.Interface())
+	}
+}
+
+func Test_deepMergeMap(t *testing.T) {
+	cases := map[string]struct {
+		dst  map[string]interface{}
+		to   interface{}
+		want map[string]interface{}
+		err  error
+	}{
+		"ErrInvalidType": {
+			dst: map[string]interface{}{},
+			to:  "not a map",
+			err: ErrSetInvalidType,
+		},
+		"MergesNestedMaps": {
+			dst:  map[string]interface{}{"a": map[string]interface{}{"x": 1}},
+			to:   map[string]interface{}{"a": map[string]interface{}{"y": 2}},
+			want: map[string]interface{}{"a": map[string]interface{}{"x": 1, "y": 2}},
+		},
+		"NewKeyOverwrites": {
+			dst:  map[string]interface{}{"a": 1},
+			to:   map[string]interface{}{"a": 2, "b": 3},
+			want: map[string]interface{}{"a": 2, "b": 3},
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dst := reflect.ValueOf(&tc.dst).Elem()
+
+			err := deepMergeMap(dst, tc.to)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("want error %+v, got %+v", tc.err, err)
+			}
+
+			if tc.want != nil && !reflect.DeepEqual(tc.want, tc.dst) {
+				t.Errorf("want %+v, got %+v", tc.want, tc.dst)
+			}
+		})
+	}
+}
+
+type testMapWriter struct {
+	WriterFunc
+	maps map[string]map[string]interface{}
+}
+
+func (w *testMapWriter) WriteMap(path Path, value map[string]interface{}) (bool, error) {
+	if path.key() != "opt" {
+		return false, nil
+	}
+
+	if w.maps == nil {
+		w.maps = make(map[string]map[string]interface{})
+	}
+
+	w.maps[path.key()] = value
+
+	return true, nil
+}
+
+func TestWalkMap_MapWriter(t *testing.T) {
+	src := map[string]interface{}{
+		"foo": "bar",
+		"opt": map[string]interface{}{"a": 1},
+		"fizz": map[string]interface{}{
+			"buzz": "fuzz",
+		},
+	}
+
+	leaves := map[string]interface{}{}
+
+	writer := &testMapWriter{
+		WriterFunc: WriterFunc(func(path Path, value interface{}) error {
+			leaves[path.key()] = value
+
+			return nil
+		}),
+	}
+
+	if err := WalkMap(context.Background(), src, Path{}, writer); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	if want := (map[string]interface{}{"a": 1}); !reflect.DeepEqual(want, writer.maps["opt"]) {
+		t.Errorf("want %+v, got %+v", want, writer.maps["opt"])
+	}
+
+	if want := "bar"; leaves["foo"] != want {
+		t.Errorf("want %+v, got %+v", want, leaves["foo"])
+	}
+
+	if want := "fuzz"; leaves["fizz.buzz"] != want {
+		t.Errorf("want %+v, got %+v", want, leaves["fizz.buzz"])
+	}
+
+	if _, ok := leaves["opt.a"]; ok {
+		t.Errorf("expected opt.a to not be flattened")
+	}
+}
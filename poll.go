@@ -0,0 +1,79 @@
+package fido
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultWatcherInterval is the poll interval WatchWithContext falls back to for a Provider that
+// implements neither NotifyProvider nor PollProvider.
+const DefaultWatcherInterval = 5 * time.Second
+
+// A PollProvider is an optional extension interface a Provider can implement to override
+// DefaultWatcherInterval/WithWatchInterval with its own poll cadence, e.g. a rate limited remote
+// API that wants a longer interval than everything else Fido is watching.
+type PollProvider interface {
+	PollInterval() time.Duration
+}
+
+// WithWatchInterval configures the poll interval WatchWithContext uses for providers that
+// implement neither NotifyProvider nor PollProvider. Default: DefaultWatcherInterval.
+func WithWatchInterval(d time.Duration) Option {
+	return OptionFunc(func(o *Options) {
+		o.WatchInterval = d
+	})
+}
+
+// pollInterval resolves the poll interval for provider: its own PollProvider interval if it
+// implements one and reports a positive duration, otherwise the Fido level WatchInterval.
+func (f *Fido) pollInterval(provider Provider) time.Duration {
+	if p, ok := unwrapPriority(provider).(PollProvider); ok {
+		if d := p.PollInterval(); d > 0 {
+			return d
+		}
+	}
+
+	if f.options.WatchInterval > 0 {
+		return f.options.WatchInterval
+	}
+
+	return DefaultWatcherInterval
+}
+
+// poll re-fetches provider on the given interval until ctx is done, giving providers with only
+// pull semantics (files, HTTP, etcd, ...) parity with NotifyProvider's push based reload. It only
+// re-fetches when AutoUpdate is enabled; otherwise each tick does nothing. fetch's existing diffing
+// in the Callback skips values equal to the field's current one, so only actual field changes are
+// published as a FieldUpdate, though a poll that found no changes still publishes an empty
+// FieldUpdates notification. A successful fetch is followed by Validate,
+// publishing a FieldValidationError rather than silently leaving the reloaded values in place if
+// it fails.
+func (f *Fido) poll(ctx context.Context, provider Provider, interval time.Duration) {
+	defer f.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if f.options.AutoUpdate {
+				if err := f.fetch(ctx, provider); err != nil {
+					f.publish(&FieldUpdateError{
+						Err: err,
+					})
+
+					continue
+				}
+
+				if err := f.Validate(ctx); err != nil {
+					f.publish(&FieldValidationError{
+						Err: err,
+					})
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package fido
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type stubPollProvider struct {
+	interval time.Duration
+}
+
+func (stubPollProvider) String() string { return "stub-poll" }
+
+func (stubPollProvider) Values(ctx context.Context, writer Writer) error { return nil }
+
+func (p stubPollProvider) PollInterval() time.Duration { return p.interval }
+
+func Test_Fido_pollInterval(t *testing.T) {
+	cases := map[string]struct {
+		provider Provider
+		watch    time.Duration
+		want     time.Duration
+	}{
+		"FallsBackToDefault": {
+			provider: stubProvider{},
+			want:     DefaultWatcherInterval,
+		},
+		"UsesFidoWatchInterval": {
+			provider: stubProvider{},
+			watch:    30 * time.Second,
+			want:     30 * time.Second,
+		},
+		"ProviderOverridesFido": {
+			provider: stubPollProvider{interval: time.Minute},
+			watch:    30 * time.Second,
+			want:     time.Minute,
+		},
+		"NonPositiveProviderIntervalFallsBackToFido": {
+			provider: stubPollProvider{interval: 0},
+			watch:    30 * time.Second,
+			want:     30 * time.Second,
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			f := &Fido{options: Options{WatchInterval: tc.watch}}
+
+			got := f.pollInterval(tc.provider)
+
+			if got != tc.want {
+				t.Errorf("want %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func Test_WithWatchInterval(t *testing.T) {
+	o := Options{}
+
+	WithWatchInterval(30 * time.Second).apply(&o)
+
+	if want := 30 * time.Second; o.WatchInterval != want {
+		t.Errorf("want %s, got %s", want, o.WatchInterval)
+	}
+}
@@ -49,10 +49,22 @@ type ReadProvider interface {
 	Values(ctx context.Context, reader io.Reader, writer Writer) error
 }
 
+// A KnownPath describes a single field Fido discovered while hydrating the destination struct, sent
+// to PathProvider.Paths so a provider can subscribe to, or pre-seed, only the keys the struct
+// actually has rather than scanning or dumping its entire source.
+type KnownPath struct {
+	Path Path
+	// Default and HasDefault carry the field's default=<value> tag option, if any.
+	Default    string
+	HasDefault bool
+	// Required carries the field's required tag option.
+	Required bool
+}
+
 // A PathProvider as an optional extension interface that if implemented by the Provider will allow
 // Fido send the known key paths inferred from the destination struct tags to the provider.
 type PathProvider interface {
-	Paths(ch <-chan []string)
+	Paths(ch <-chan KnownPath)
 }
 
 // FromString constructs a new StringProvider.
@@ -109,13 +121,68 @@ func (s *BytesProvider) Values(ctx context.Context, writer Writer) error {
 	return s.provider.Values(ctx, bytes.NewReader(s.value), writer)
 }
 
+// A prioritizedProvider is implemented by a Provider wrapped with WithPriority, letting
+// providers.add consult the explicit priority it carries instead of assigning one based on add
+// order.
+type prioritizedProvider interface {
+	Provider
+
+	priority() uint8
+}
+
+// WithPriority wraps provider so Add gives it the given priority explicitly, rather than the
+// priority it would otherwise be assigned based on the order it, and every other provider, was
+// added in. Higher wins: with EnforcePriority enabled, a field set by a priority 5 provider cannot
+// be overwritten by one at priority 1, regardless of which was added, or fetched, first, letting a
+// caller express e.g. "vault beats file beats env" in a few lines.
+func WithPriority(provider Provider, priority uint8) Provider {
+	return &priorityProvider{Provider: provider, p: priority}
+}
+
+// priorityProvider wraps a Provider with an explicit priority, implementing prioritizedProvider.
+// Its String embeds the wrapped Provider's own, so logging and errors still name the real source.
+type priorityProvider struct {
+	Provider
+
+	p uint8
+}
+
+func (p *priorityProvider) priority() uint8 {
+	return p.p
+}
+
+// unwrapPriority returns the Provider a WithPriority wrapper embeds, or provider unchanged if it is
+// not one. Since priorityProvider embeds Provider as an interface, Go only promotes the methods
+// Provider itself declares, not whatever optional extension interface (CloseProvider,
+// NotifyProvider, PathProvider, PollProvider) the wrapped concrete value also happens to implement
+// — so every such check is run against unwrapPriority's result rather than provider directly, to
+// avoid silently losing a capability a WithPriority-wrapped provider still has.
+func unwrapPriority(provider Provider) Provider {
+	if pp, ok := provider.(*priorityProvider); ok {
+		return pp.Provider
+	}
+
+	return provider
+}
+
 type providers map[Provider]uint8
 
+// add assigns each provider not already known a priority: the one given explicitly via
+// WithPriority if it implements prioritizedProvider, otherwise the next sequential priority based
+// on how many providers are already known.
 func (p providers) add(items ...Provider) {
 	for _, provider := range items {
-		if _, ok := p[provider]; !ok {
-			p[provider] = uint8(len(p) + 1)
+		if _, ok := p[provider]; ok {
+			continue
 		}
+
+		if pp, ok := provider.(prioritizedProvider); ok {
+			p[provider] = pp.priority()
+
+			continue
+		}
+
+		p[provider] = uint8(len(p) + 1)
 	}
 }
 
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -19,21 +20,21 @@ type TestValue struct {
 type TestProvider struct {
 	t      *testing.T
 	values []TestValue
-	fn     func(context.Context, Callback) error
+	fn     func(context.Context, Writer) error
 }
 
 func (t *TestProvider) String() string {
 	return "TestProvider"
 }
 
-func (t *TestProvider) Values(ctx context.Context, callback Callback) error {
+func (t *TestProvider) Values(ctx context.Context, writer Writer) error {
 	if t.fn == nil {
 		for _, v := range t.values {
 			if v.Err != nil {
 				return v.Err
 			}
 
-			if err := callback(v.Path, v.Value); err != nil {
+			if err := writer.Write(v.Path, v.Value); err != nil {
 				return err
 			}
 		}
@@ -41,7 +42,7 @@ func (t *TestProvider) Values(ctx context.Context, callback Callback) error {
 		return nil
 	}
 
-	return t.fn(ctx, callback)
+	return t.fn(ctx, writer)
 }
 
 func (t *TestProvider) Add(path []string, value interface{}, err error) {
@@ -55,7 +56,7 @@ func NewTestProvider(t *testing.T) *TestProvider {
 	}
 }
 
-func NewTestProviderWithFunc(t *testing.T, fn func(context.Context, Callback) error) *TestProvider {
+func NewTestProviderWithFunc(t *testing.T, fn func(context.Context, Writer) error) *TestProvider {
 	return &TestProvider{
 		t:      t,
 		values: make([]TestValue, 0),
@@ -65,38 +66,75 @@ func NewTestProviderWithFunc(t *testing.T, fn func(context.Context, Callback) er
 
 type TestReadProvider struct {
 	t  *testing.T
-	fn func(context.Context, io.Reader, Callback) error
+	fn func(context.Context, io.Reader, Writer) error
 }
 
 func (t *TestReadProvider) String() string {
 	return "TestReadProvider"
 }
 
-func (t *TestReadProvider) Values(ctx context.Context, reader io.Reader, callback Callback) error {
+func (t *TestReadProvider) Values(ctx context.Context, reader io.Reader, writer Writer) error {
 	if t.fn == nil {
 		t.t.Error("values function defined")
 		return nil
 	}
 
-	return t.fn(ctx, reader, callback)
+	return t.fn(ctx, reader, writer)
 }
 
-func NewTestReadProvider(t *testing.T, fn func(context.Context, io.Reader, Callback) error) *TestReadProvider {
+func NewTestReadProvider(t *testing.T, fn func(context.Context, io.Reader, Writer) error) *TestReadProvider {
 	return &TestReadProvider{
 		t:  t,
 		fn: fn,
 	}
 }
 
+// TestPathProvider wraps a TestProvider and also implements PathProvider, recording every KnownPath
+// it is sent so tests can assert on what Fido.Add streamed it.
+type TestPathProvider struct {
+	*TestProvider
+
+	mu    sync.Mutex
+	paths []KnownPath
+	done  chan struct{}
+}
+
+func NewTestPathProvider(t *testing.T) *TestPathProvider {
+	return &TestPathProvider{
+		TestProvider: NewTestProvider(t),
+		done:         make(chan struct{}),
+	}
+}
+
+func (t *TestPathProvider) Paths(ch <-chan KnownPath) {
+	defer close(t.done)
+
+	for kp := range ch {
+		t.mu.Lock()
+		t.paths = append(t.paths, kp)
+		t.mu.Unlock()
+	}
+}
+
+// Received blocks until Paths has drained its channel, then returns every KnownPath it received.
+func (t *TestPathProvider) Received() []KnownPath {
+	<-t.done
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.paths
+}
+
 func TestStringProvider(t *testing.T) {
 	cases := map[string]struct {
 		provider func(*testing.T) ReadProvider
-		callback func(*testing.T) Callback
+		callback func(*testing.T) Writer
 		err      error
 	}{
 		"PassesValues": {
 			provider: func(t *testing.T) ReadProvider {
-				return NewTestReadProvider(t, func(ctx context.Context, reader io.Reader, callback Callback) error {
+				return NewTestReadProvider(t, func(ctx context.Context, reader io.Reader, writer Writer) error {
 					b, err := ioutil.ReadAll(reader)
 					if err != nil {
 						return err
@@ -104,11 +142,11 @@ func TestStringProvider(t *testing.T) {
 
 					parts := strings.Split(string(b), ":")
 
-					return callback(Path{parts[0]}, parts[1])
+					return writer.Write(Path{parts[0]}, parts[1])
 				})
 			},
-			callback: func(t *testing.T) Callback {
-				return func(path Path, value interface{}) error {
+			callback: func(t *testing.T) Writer {
+				return WriterFunc(func(path Path, value interface{}) error {
 					{
 						want := Path{"foo"}
 						if !reflect.DeepEqual(path, want) {
@@ -124,7 +162,7 @@ func TestStringProvider(t *testing.T) {
 					}
 
 					return nil
-				}
+				})
 			},
 		},
 	}
@@ -153,12 +191,12 @@ func TestStringProvider(t *testing.T) {
 func TestBytesProvider(t *testing.T) {
 	cases := map[string]struct {
 		provider func(*testing.T) ReadProvider
-		callback func(*testing.T) Callback
+		callback func(*testing.T) Writer
 		err      error
 	}{
 		"PassesValues": {
 			provider: func(t *testing.T) ReadProvider {
-				return NewTestReadProvider(t, func(ctx context.Context, reader io.Reader, callback Callback) error {
+				return NewTestReadProvider(t, func(ctx context.Context, reader io.Reader, writer Writer) error {
 					b, err := ioutil.ReadAll(reader)
 					if err != nil {
 						return err
@@ -166,11 +204,11 @@ func TestBytesProvider(t *testing.T) {
 
 					parts := strings.Split(string(b), ":")
 
-					return callback(Path{parts[0]}, parts[1])
+					return writer.Write(Path{parts[0]}, parts[1])
 				})
 			},
-			callback: func(t *testing.T) Callback {
-				return func(path Path, value interface{}) error {
+			callback: func(t *testing.T) Writer {
+				return WriterFunc(func(path Path, value interface{}) error {
 					{
 						want := Path{"foo"}
 						if !reflect.DeepEqual(path, want) {
@@ -186,7 +224,7 @@ func TestBytesProvider(t *testing.T) {
 					}
 
 					return nil
-				}
+				})
 			},
 		},
 	}
@@ -251,3 +289,47 @@ func Test_providers_priority(t *testing.T) {
 		})
 	}
 }
+
+func Test_WithPriority(t *testing.T) {
+	provider1 := NewTestProvider(t)
+	prioritized := WithPriority(NewTestProvider(t), 10)
+	provider3 := NewTestProvider(t)
+
+	p := make(providers)
+	p.add(provider1)
+	p.add(prioritized)
+	p.add(provider3)
+
+	if want, got := uint8(1), p.priority(provider1); want != got {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+
+	if want, got := uint8(10), p.priority(prioritized); want != got {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+
+	if want, got := uint8(3), p.priority(provider3); want != got {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+// Test_unwrapPriority asserts that a WithPriority-wrapped provider still type-asserts as whatever
+// optional extension interface the provider it wraps implements, once unwrapped.
+func Test_unwrapPriority(t *testing.T) {
+	plain := NewTestProvider(t)
+
+	if got := unwrapPriority(plain); got != Provider(plain) {
+		t.Errorf("want unwrapPriority to return an unwrapped provider unchanged, got %+v", got)
+	}
+
+	pathProvider := NewTestPathProvider(t)
+	wrapped := WithPriority(pathProvider, 10)
+
+	if _, ok := wrapped.(PathProvider); ok {
+		t.Error("want a WithPriority-wrapped PathProvider to not satisfy PathProvider directly")
+	}
+
+	if _, ok := unwrapPriority(wrapped).(PathProvider); !ok {
+		t.Error("want unwrapPriority(wrapped) to satisfy PathProvider")
+	}
+}
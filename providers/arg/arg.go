@@ -0,0 +1,113 @@
+// Package arg provides a fido.Provider that resolves configuration values from command line flags.
+package arg
+
+import (
+	"context"
+	"strings"
+
+	"github.com/krak3n/fido"
+)
+
+// ProviderName is the name of the provider.
+const ProviderName = "arg"
+
+// An Option configures provider behaviour.
+type Option interface {
+	apply(*Provider)
+}
+
+// OptionFunc is an adapter allowing regular functions to act as Options.
+type OptionFunc func(*Provider)
+
+func (fn OptionFunc) apply(p *Provider) {
+	fn(p)
+}
+
+// WithSeparator configures the separator a flag name is split on to form a fido.Path, e.g.
+// "--database.host=localhost" becomes Path{"database", "host"}. Default: "."
+func WithSeparator(sep string) Option {
+	return OptionFunc(func(p *Provider) {
+		p.separator = sep
+	})
+}
+
+// Provider implements a fido.Provider that resolves configuration values from "--foo.bar=value"
+// and "--foo.bar value" style command line flags.
+type Provider struct {
+	args      []string
+	separator string
+}
+
+// New constructs a Provider parsing args, typically os.Args[1:].
+func New(args []string, opts ...Option) *Provider {
+	p := &Provider{
+		args:      args,
+		separator: ".",
+	}
+
+	for _, opt := range opts {
+		opt.apply(p)
+	}
+
+	return p
+}
+
+func (p *Provider) String() string {
+	return ProviderName
+}
+
+// Values parses args for "--name=value" and "--name value" style flags, splitting each name on
+// separator to form a fido.Path, sending every one it finds to the writer.
+func (p *Provider) Values(ctx context.Context, writer fido.Writer) error {
+	for i := 0; i < len(p.args); i++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		name, ok := flagName(p.args[i])
+		if !ok {
+			continue
+		}
+
+		if name, value, ok := strings.Cut(name, "="); ok {
+			if err := writer.Write(p.path(name), value); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if i+1 >= len(p.args) {
+			continue
+		}
+
+		if _, ok := flagName(p.args[i+1]); ok {
+			continue
+		}
+
+		if err := writer.Write(p.path(name), p.args[i+1]); err != nil {
+			return err
+		}
+
+		i++
+	}
+
+	return nil
+}
+
+// flagName strips the leading "--" from arg, reporting false if arg is not a long flag.
+func flagName(arg string) (string, bool) {
+	name, ok := strings.CutPrefix(arg, "--")
+	if !ok || name == "" {
+		return "", false
+	}
+
+	return name, true
+}
+
+// path splits name on separator to form a fido.Path.
+func (p *Provider) path(name string) fido.Path {
+	return fido.Path(strings.Split(name, p.separator))
+}
@@ -0,0 +1,33 @@
+package arg_test
+
+import (
+	"fmt"
+
+	"github.com/krak3n/fido"
+	"github.com/krak3n/fido/providers/arg"
+)
+
+func Example() {
+	type Config struct {
+		Host string `fido:"host"`
+	}
+
+	var cfg Config
+
+	provider := arg.New([]string{"--host=localhost"})
+
+	f, err := fido.New(&cfg)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	defer f.Close()
+
+	if err := f.Fetch(provider); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Printf("%+v", cfg)
+	// Output:
+	// {Host:localhost}
+}
@@ -0,0 +1,115 @@
+package arg
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/krak3n/fido"
+)
+
+func TestProvider_Values(t *testing.T) {
+	type want struct {
+		path  fido.Path
+		value interface{}
+	}
+
+	cases := map[string]struct {
+		ctx  context.Context
+		args []string
+		opts []Option
+		want []want
+		err  error
+	}{
+		"ErrContextCancelled": {
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				return ctx
+			}(),
+			args: []string{"--foo.bar=fizz"},
+			want: []want{},
+			err:  context.Canceled,
+		},
+		"ParsesEqualsForm": {
+			ctx:  context.Background(),
+			args: []string{"--foo.bar=fizz"},
+			want: []want{
+				{path: fido.Path{"foo", "bar"}, value: "fizz"},
+			},
+		},
+		"ParsesSpaceForm": {
+			ctx:  context.Background(),
+			args: []string{"--foo.bar", "fizz"},
+			want: []want{
+				{path: fido.Path{"foo", "bar"}, value: "fizz"},
+			},
+		},
+		"BoolFlagWithNoValueIsSkipped": {
+			ctx:  context.Background(),
+			args: []string{"--verbose", "--foo.bar=fizz"},
+			want: []want{
+				{path: fido.Path{"foo", "bar"}, value: "fizz"},
+			},
+		},
+		"CustomSeparator": {
+			ctx:  context.Background(),
+			args: []string{"--foo/bar=fizz"},
+			opts: []Option{WithSeparator("/")},
+			want: []want{
+				{path: fido.Path{"foo", "bar"}, value: "fizz"},
+			},
+		},
+		"IgnoresPositionalArgs": {
+			ctx:  context.Background(),
+			args: []string{"serve", "--foo.bar=fizz"},
+			want: []want{
+				{path: fido.Path{"foo", "bar"}, value: "fizz"},
+			},
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var i int
+
+			writer := fido.WriterFunc(func(p fido.Path, v interface{}) error {
+				if i+1 > len(tc.want) {
+					t.Fatal("received more than expected values")
+				}
+
+				want := tc.want[i]
+
+				if !reflect.DeepEqual(want.path, p) {
+					t.Errorf("want %+v path, got %+v", want.path, p)
+				}
+
+				if !reflect.DeepEqual(want.value, v) {
+					t.Errorf("want %+v value, got %+v", want.value, v)
+				}
+
+				i++
+
+				return nil
+			})
+
+			p := New(tc.args, tc.opts...)
+
+			err := p.Values(tc.ctx, writer)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("want %+v error got %+v", tc.err, err)
+			}
+
+			if i != len(tc.want) {
+				t.Errorf("want %d values, got %d", len(tc.want), i)
+			}
+		})
+	}
+}
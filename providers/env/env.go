@@ -0,0 +1,239 @@
+// Package env provides a fido.Provider that resolves configuration values from environment
+// variables, optionally pre-populated from a .env file.
+package env
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/krak3n/fido"
+)
+
+// ProviderName is the name of the provider.
+const ProviderName = "env"
+
+// A NameMapper translates a fido.Path into an environment variable name.
+type NameMapper func(fido.Path) string
+
+// SnakeUpper joins path segments with an underscore and upper cases the result, e.g.
+// []string{"database", "max", "conns"} becomes "DATABASE_MAX_CONNS".
+func SnakeUpper(path fido.Path) string {
+	return strings.ToUpper(strings.Join(path, "_"))
+}
+
+// SnakeLower joins path segments with an underscore and lower cases the result, e.g.
+// []string{"database", "max", "conns"} becomes "database_max_conns".
+func SnakeLower(path fido.Path) string {
+	return strings.ToLower(strings.Join(path, "_"))
+}
+
+// KebabUpper joins path segments with a hyphen and upper cases the result, e.g.
+// []string{"database", "max", "conns"} becomes "DATABASE-MAX-CONNS".
+func KebabUpper(path fido.Path) string {
+	return strings.ToUpper(strings.Join(path, "-"))
+}
+
+// An Option configures provider behaviour.
+type Option interface {
+	apply(*Provider)
+}
+
+// OptionFunc is an adapter allowing regular functions to act as Options.
+type OptionFunc func(*Provider)
+
+func (fn OptionFunc) apply(p *Provider) {
+	fn(p)
+}
+
+// WithPrefix configures a prefix prepended, followed by the separator, to every mapped
+// environment variable name, e.g. prefix "APP" turns "DATABASE_MAX_CONNS" into
+// "APP_DATABASE_MAX_CONNS".
+func WithPrefix(prefix string) Option {
+	return OptionFunc(func(p *Provider) {
+		p.prefix = prefix
+	})
+}
+
+// WithSeparator configures the separator used to join a configured prefix to the mapped
+// environment variable name. Default: "_".
+func WithSeparator(sep string) Option {
+	return OptionFunc(func(p *Provider) {
+		p.separator = sep
+	})
+}
+
+// WithNameMapper configures the function used to translate a fido.Path into an environment
+// variable name. Default: SnakeUpper.
+func WithNameMapper(fn NameMapper) Option {
+	return OptionFunc(func(p *Provider) {
+		p.mapper = fn
+	})
+}
+
+// WithDotenvFile pre-populates the provider from the given .env file. Values found in the process
+// environment always take precedence over values loaded from the file.
+func WithDotenvFile(path string) Option {
+	return OptionFunc(func(p *Provider) {
+		p.dotenv = path
+	})
+}
+
+// Provider implements a fido.Provider that resolves configuration values from environment
+// variables.
+type Provider struct {
+	paths     func() []fido.Path
+	prefix    string
+	separator string
+	mapper    NameMapper
+	dotenv    string
+	dotenvSet bool
+	lookup    func(string) (string, bool)
+	signals   chan os.Signal
+}
+
+// New constructs a new Provider. paths is called each time Values or Notify run to determine which
+// environment variables to look up, typically (*fido.Fido).Paths.
+func New(paths func() []fido.Path, opts ...Option) *Provider {
+	p := &Provider{
+		paths:     paths,
+		separator: "_",
+		mapper:    SnakeUpper,
+		lookup:    os.LookupEnv,
+		signals:   make(chan os.Signal, 1),
+	}
+
+	for _, opt := range opts {
+		opt.apply(p)
+	}
+
+	return p
+}
+
+func (p *Provider) String() string {
+	return ProviderName
+}
+
+// Values walks the Paths given by the configured paths function, looking up each mapped
+// environment variable name and sending any that are set to the writer.
+func (p *Provider) Values(ctx context.Context, writer fido.Writer) error {
+	if p.dotenv != "" && !p.dotenvSet {
+		if err := p.loadDotenv(); err != nil {
+			return err
+		}
+
+		p.dotenvSet = true
+	}
+
+	for _, path := range p.paths() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			v, ok := p.lookup(p.name(path))
+			if !ok {
+				continue
+			}
+
+			if err := writer.Write(path, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Notify implements the optional fido.NotifyProvider extension interface. It re-runs Values,
+// sending any environment variables that are now set, each time the process receives SIGHUP.
+func (p *Provider) Notify(ctx context.Context, writer fido.Writer) error {
+	signal.Notify(p.signals, syscall.SIGHUP)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case _, ok := <-p.signals:
+			if !ok {
+				return nil
+			}
+
+			if err := p.Values(ctx, writer); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Close implements the optional fido.CloseProvider extension interface, stopping the SIGHUP
+// notifications registered by Notify.
+func (p *Provider) Close() error {
+	signal.Stop(p.signals)
+	close(p.signals)
+
+	return nil
+}
+
+// name maps a fido.Path to the environment variable name it is resolved from.
+func (p *Provider) name(path fido.Path) string {
+	name := p.mapper(path)
+
+	if p.prefix == "" {
+		return name
+	}
+
+	return p.prefix + p.separator + name
+}
+
+// loadDotenv reads KEY=VALUE pairs from the configured dotenv file, falling back to them only when
+// the process environment does not already have the key set.
+func (p *Provider) loadDotenv() error {
+	f, err := os.Open(p.dotenv)
+	if err != nil {
+		return fmt.Errorf("%w: failed to open dotenv file %s", err, p.dotenv)
+	}
+
+	defer f.Close()
+
+	values := make(map[string]string)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		key := strings.TrimSpace(parts[0])
+		value := strings.Trim(strings.TrimSpace(parts[1]), `"'`)
+
+		values[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%w: failed to read dotenv file %s", err, p.dotenv)
+	}
+
+	lookup := p.lookup
+
+	p.lookup = func(name string) (string, bool) {
+		if v, ok := lookup(name); ok {
+			return v, true
+		}
+
+		v, ok := values[name]
+
+		return v, ok
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package env_test
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/krak3n/fido"
+	"github.com/krak3n/fido/providers/env"
+)
+
+func Example() {
+	type Config struct {
+		Foo string `fido:"foo"`
+	}
+
+	var cfg Config
+
+	os.Setenv("APP_FOO", "bar")
+	defer os.Unsetenv("APP_FOO")
+
+	f, err := fido.New(&cfg)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	defer f.Close()
+
+	provider := env.New(f.Paths, env.WithPrefix("APP"))
+
+	if err := f.Fetch(provider); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Printf("%+v", cfg)
+	// Output:
+	// {Foo:bar}
+}
@@ -0,0 +1,186 @@
+package env
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/krak3n/fido"
+)
+
+func TestSnakeUpper(t *testing.T) {
+	t.Parallel()
+
+	got := SnakeUpper(fido.Path{"database", "max", "conns"})
+	want := "DATABASE_MAX_CONNS"
+
+	if got != want {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
+
+func TestSnakeLower(t *testing.T) {
+	t.Parallel()
+
+	got := SnakeLower(fido.Path{"database", "max", "conns"})
+	want := "database_max_conns"
+
+	if got != want {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
+
+func TestKebabUpper(t *testing.T) {
+	t.Parallel()
+
+	got := KebabUpper(fido.Path{"database", "max", "conns"})
+	want := "DATABASE-MAX-CONNS"
+
+	if got != want {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
+
+func TestProvider(t *testing.T) {
+	type want struct {
+		path  fido.Path
+		value interface{}
+	}
+
+	cases := map[string]struct {
+		ctx   context.Context
+		paths []fido.Path
+		env   map[string]string
+		opts  []Option
+		want  []want
+		err   error
+	}{
+		"ErrContextCancelled": {
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				return ctx
+			}(),
+			paths: []fido.Path{{"foo", "bar"}},
+			env:   map[string]string{"FOO_BAR": "fizz"},
+			want:  []want{},
+			err:   context.Canceled,
+		},
+		"OnlyResolvesKnownPaths": {
+			ctx:   context.Background(),
+			paths: []fido.Path{{"foo", "bar"}},
+			env: map[string]string{
+				"FOO_BAR":     "fizz",
+				"UNKNOWN_KEY": "ignored",
+			},
+			want: []want{
+				{path: fido.Path{"foo", "bar"}, value: "fizz"},
+			},
+		},
+		"SkipsUnsetValues": {
+			ctx:   context.Background(),
+			paths: []fido.Path{{"foo", "bar"}},
+			want:  []want{},
+		},
+		"WithPrefix": {
+			ctx:   context.Background(),
+			paths: []fido.Path{{"foo", "bar"}},
+			env:   map[string]string{"APP_FOO_BAR": "fizz"},
+			opts:  []Option{WithPrefix("APP")},
+			want: []want{
+				{path: fido.Path{"foo", "bar"}, value: "fizz"},
+			},
+		},
+		"WithNameMapper": {
+			ctx:   context.Background(),
+			paths: []fido.Path{{"foo", "bar"}},
+			env:   map[string]string{"FOO-BAR": "fizz"},
+			opts:  []Option{WithNameMapper(KebabUpper)},
+			want: []want{
+				{path: fido.Path{"foo", "bar"}, value: "fizz"},
+			},
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			var i int
+
+			writer := fido.WriterFunc(func(p fido.Path, v interface{}) error {
+				if i+1 > len(tc.want) {
+					t.Fatal("received more than expected values")
+				}
+
+				want := tc.want[i]
+
+				if !reflect.DeepEqual(want.path, p) {
+					t.Errorf("want %+v path, got %+v", want.path, p)
+				}
+
+				if !reflect.DeepEqual(want.value, v) {
+					t.Errorf("want %+v value, got %+v", want.value, v)
+				}
+
+				i++
+
+				return nil
+			})
+
+			provider := New(func() []fido.Path { return tc.paths }, tc.opts...)
+
+			err := provider.Values(tc.ctx, writer)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("want %+v error got %+v", tc.err, err)
+			}
+
+			if i != len(tc.want) {
+				t.Errorf("want %d values, got %d", len(tc.want), i)
+			}
+		})
+	}
+}
+
+func TestProvider_WithDotenvFile(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, ".env")
+
+	if err := os.WriteFile(path, []byte("# comment\nFOO_BAR=fizz\nFIZZ_BUZZ=\"bazz\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("FIZZ_BUZZ", "overridden")
+
+	var values []interface{}
+
+	writer := fido.WriterFunc(func(p fido.Path, v interface{}) error {
+		values = append(values, v)
+
+		return nil
+	})
+
+	provider := New(func() []fido.Path {
+		return []fido.Path{{"foo", "bar"}, {"fizz", "buzz"}}
+	}, WithDotenvFile(path))
+
+	if err := provider.Values(context.Background(), writer); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []interface{}{"fizz", "overridden"}
+
+	if !reflect.DeepEqual(want, values) {
+		t.Errorf("want %+v, got %+v", want, values)
+	}
+}
@@ -0,0 +1,129 @@
+// Package etcd provides a fido.Provider that resolves configuration values from an etcd key/value
+// prefix.
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/krak3n/fido"
+)
+
+// ProviderName is the name of the provider.
+const ProviderName = "etcd"
+
+// An Option configures provider behaviour.
+type Option interface {
+	apply(*Provider)
+}
+
+// OptionFunc is an adapter allowing regular functions to act as Options.
+type OptionFunc func(*Provider)
+
+func (fn OptionFunc) apply(p *Provider) {
+	fn(p)
+}
+
+// WithSeparator configures the separator a key, with prefix stripped, is split on to form a
+// fido.Path, e.g. prefix "/config/" and key "/config/database/host" becomes Path{"database",
+// "host"}. Default: "/"
+func WithSeparator(sep string) Option {
+	return OptionFunc(func(p *Provider) {
+		p.separator = sep
+	})
+}
+
+// Provider implements a fido.Provider that resolves configuration values from an etcd key/value
+// prefix.
+type Provider struct {
+	get       func(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error)
+	watch     func(ctx context.Context, key string, opts ...clientv3.OpOption) clientv3.WatchChan
+	close     func() error
+	prefix    string
+	separator string
+}
+
+// New constructs a Provider reading every key under prefix from the given etcd client.
+func New(client *clientv3.Client, prefix string, opts ...Option) *Provider {
+	p := &Provider{
+		get:       client.Get,
+		watch:     client.Watch,
+		close:     client.Close,
+		prefix:    prefix,
+		separator: "/",
+	}
+
+	for _, opt := range opts {
+		opt.apply(p)
+	}
+
+	return p
+}
+
+func (p *Provider) String() string {
+	return ProviderName
+}
+
+// Values does a prefix Get against prefix, sending every key/value pair it finds to the writer,
+// with prefix stripped from the key and the remainder split on separator to form a fido.Path.
+func (p *Provider) Values(ctx context.Context, writer fido.Writer) error {
+	resp, err := p.get(ctx, p.prefix, clientv3.WithPrefix())
+	if err != nil {
+		return fmt.Errorf("%w: failed to get etcd prefix %s", err, p.prefix)
+	}
+
+	for _, kv := range resp.Kvs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := writer.Write(p.path(string(kv.Key)), string(kv.Value)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// path strips prefix from key and splits the remainder on separator to form a fido.Path.
+func (p *Provider) path(key string) fido.Path {
+	key = strings.TrimPrefix(key, p.prefix)
+	key = strings.Trim(key, p.separator)
+
+	return fido.Path(strings.Split(key, p.separator))
+}
+
+// Notify implements the optional fido.NotifyProvider extension interface, streaming etcd's native
+// watch events for keys under prefix back to Fido as they are written, so a key updated in etcd
+// flows back into the bound struct.
+func (p *Provider) Notify(ctx context.Context, writer fido.Writer) error {
+	watch := p.watch(ctx, p.prefix, clientv3.WithPrefix())
+
+	for resp := range watch {
+		if err := resp.Err(); err != nil {
+			return fmt.Errorf("%w: etcd watch error", err)
+		}
+
+		for _, event := range resp.Events {
+			if event.Type != clientv3.EventTypePut {
+				continue
+			}
+
+			if err := writer.Write(p.path(string(event.Kv.Key)), string(event.Kv.Value)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return ctx.Err()
+}
+
+// Close implements the optional fido.CloseProvider extension interface, closing the underlying etcd
+// client.
+func (p *Provider) Close() error {
+	return p.close()
+}
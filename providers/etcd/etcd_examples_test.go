@@ -0,0 +1,43 @@
+package etcd_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/krak3n/fido"
+	"github.com/krak3n/fido/providers/etcd"
+)
+
+func Example() {
+	type Config struct {
+		Host string `fido:"database/host"`
+	}
+
+	var cfg Config
+
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   []string{"127.0.0.1:2379"},
+		DialTimeout: 5 * time.Second,
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	f, err := fido.New(&cfg)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	defer f.Close()
+
+	provider := etcd.New(client, "/config/")
+
+	if err := f.FetchWithContext(context.Background(), provider); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Printf("%+v", cfg)
+}
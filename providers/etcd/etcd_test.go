@@ -0,0 +1,144 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	mvccpb "go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/krak3n/fido"
+)
+
+var errBoom = errors.New("boom")
+
+func TestProvider_Values(t *testing.T) {
+	type want struct {
+		path  fido.Path
+		value interface{}
+	}
+
+	cases := map[string]struct {
+		ctx    context.Context
+		get    func(context.Context, string, ...clientv3.OpOption) (*clientv3.GetResponse, error)
+		prefix string
+		opts   []Option
+		want   []want
+		err    error
+	}{
+		"ErrContextCancelled": {
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				return ctx
+			}(),
+			prefix: "/config/",
+			get: func(context.Context, string, ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+				return &clientv3.GetResponse{
+					Kvs: []*mvccpb.KeyValue{
+						{Key: []byte("/config/foo/bar"), Value: []byte("fizz")},
+					},
+				}, nil
+			},
+			want: []want{},
+			err:  context.Canceled,
+		},
+		"SendsValues": {
+			ctx:    context.Background(),
+			prefix: "/config/",
+			get: func(context.Context, string, ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+				return &clientv3.GetResponse{
+					Kvs: []*mvccpb.KeyValue{
+						{Key: []byte("/config/foo/bar"), Value: []byte("fizz")},
+					},
+				}, nil
+			},
+			want: []want{
+				{path: fido.Path{"foo", "bar"}, value: "fizz"},
+			},
+		},
+		"CustomSeparator": {
+			ctx:    context.Background(),
+			prefix: "/config/",
+			get: func(context.Context, string, ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+				return &clientv3.GetResponse{
+					Kvs: []*mvccpb.KeyValue{
+						{Key: []byte("/config/foo.bar"), Value: []byte("fizz")},
+					},
+				}, nil
+			},
+			opts: []Option{WithSeparator(".")},
+			want: []want{
+				{path: fido.Path{"foo", "bar"}, value: "fizz"},
+			},
+		},
+		"ErrGet": {
+			ctx:    context.Background(),
+			prefix: "/config/",
+			get: func(context.Context, string, ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+				return nil, errBoom
+			},
+			want: []want{},
+			err:  errBoom,
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var i int
+
+			writer := fido.WriterFunc(func(p fido.Path, v interface{}) error {
+				if i+1 > len(tc.want) {
+					t.Fatal("received more than expected values")
+				}
+
+				want := tc.want[i]
+
+				if !reflect.DeepEqual(want.path, p) {
+					t.Errorf("want %+v path, got %+v", want.path, p)
+				}
+
+				if !reflect.DeepEqual(want.value, v) {
+					t.Errorf("want %+v value, got %+v", want.value, v)
+				}
+
+				i++
+
+				return nil
+			})
+
+			p := &Provider{get: tc.get, prefix: tc.prefix, separator: "/"}
+			for _, opt := range tc.opts {
+				opt.apply(p)
+			}
+
+			err := p.Values(tc.ctx, writer)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("want %+v error got %+v", tc.err, err)
+			}
+
+			if i != len(tc.want) {
+				t.Errorf("want %d values, got %d", len(tc.want), i)
+			}
+		})
+	}
+}
+
+func TestProvider_path(t *testing.T) {
+	p := &Provider{prefix: "/config/", separator: "/"}
+
+	got := p.path("/config/database/host")
+	want := fido.Path{"database", "host"}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
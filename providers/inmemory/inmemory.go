@@ -84,19 +84,29 @@ func (p *Provider) Values(ctx context.Context, writer fido.Writer) error {
 }
 
 // Notify implements the optional NotifyProvider extension interface sending notifications of
-// changes to configuration values handled by this provider. This blocks until Close is called.
+// changes to configuration values handled by this provider. This blocks until ctx is done or Close
+// is called, whichever happens first, rather than relying on Close alone: Close races closing this
+// channel against Notify registering it above, so a caller cancelling ctx is the only reliable way
+// to stop this from blocking forever.
 func (p *Provider) Notify(ctx context.Context, writer fido.Writer) error {
 	ch := make(chan string)
 
 	p.notifications = append(p.notifications, ch)
 
-	for path := range ch {
-		if err := writer.Write(fido.Path(strings.Split(path, p.separator)), p.values[path]); err != nil {
-			return err
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case path, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := writer.Write(fido.Path(strings.Split(path, p.separator)), p.values[path]); err != nil {
+				return err
+			}
 		}
 	}
-
-	return nil
 }
 
 // Close implements the optional NotifyCloser extension interface closing any notification channels
@@ -0,0 +1,44 @@
+package toml
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/krak3n/fido"
+)
+
+// ProviderName is the name of the Provider.
+const ProviderName = "toml"
+
+// Provider provides a TOML fido.ReadProvider.
+type Provider struct{}
+
+// New constructs a new Provider.
+func New() *Provider {
+	return &Provider{}
+}
+
+func (p *Provider) String() string {
+	return ProviderName
+}
+
+// Values reads TOML from the given io.Reader, unmarshalling it into the same map[string]interface{}
+// shape the JSON and YAML providers produce, then passes the values back to Fido for processing.
+func (p *Provider) Values(ctx context.Context, reader io.Reader, writer fido.Writer) error {
+	b, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	var dst map[string]interface{}
+
+	if err := toml.Unmarshal(b, &dst); err != nil {
+		return fmt.Errorf("%w: failed to unmarshal TOML: '%s'", err, string(b))
+	}
+
+	return fido.WalkMap(ctx, dst, fido.Path{}, writer)
+}
@@ -0,0 +1,97 @@
+package toml
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/krak3n/fido"
+)
+
+func TestProvider_Values(t *testing.T) {
+	type want struct {
+		path  fido.Path
+		value interface{}
+	}
+
+	cases := map[string]struct {
+		ctx        context.Context
+		toml       string
+		want       []want
+		err        error
+		errUnmarshal bool
+	}{
+		"ErrContextCancelled": {
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				return ctx
+			}(),
+			toml: `foo = "bar"`,
+			err:  context.Canceled,
+		},
+		"InvalidTOML": {
+			ctx:        context.Background(),
+			toml:       "foo = [",
+			errUnmarshal: true,
+		},
+		"SendsValues": {
+			ctx: context.Background(),
+			toml: `
+foo = "bar"
+[fizz]
+buzz = "bazz"
+`,
+			want: []want{
+				{path: fido.Path{"foo"}, value: "bar"},
+				{path: fido.Path{"fizz", "buzz"}, value: "bazz"},
+			},
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []want
+
+			writer := fido.WriterFunc(func(p fido.Path, v interface{}) error {
+				got = append(got, want{path: p, value: v})
+
+				return nil
+			})
+
+			err := New().Values(tc.ctx, strings.NewReader(tc.toml), writer)
+
+			switch {
+			case tc.errUnmarshal:
+				if err == nil || !strings.Contains(err.Error(), "failed to unmarshal TOML") {
+					t.Errorf("want unmarshal error, got %+v", err)
+				}
+			default:
+				if !errors.Is(err, tc.err) {
+					t.Errorf("want %+v error, got %+v", tc.err, err)
+				}
+			}
+
+			byPath := func(s []want) func(i, j int) bool {
+				return func(i, j int) bool {
+					return strings.Join(s[i].path, ".") < strings.Join(s[j].path, ".")
+				}
+			}
+
+			sort.Slice(tc.want, byPath(tc.want))
+			sort.Slice(got, byPath(got))
+
+			if !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("want %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+// Package vault provides a fido.Provider that resolves configuration values from a HashiCorp Vault
+// KV v2 secret.
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+
+	"github.com/krak3n/fido"
+)
+
+// ProviderName is the name of the provider.
+const ProviderName = "vault"
+
+// defaultPollInterval is the poll interval New gives a Provider; WithWatch, which makes Notify
+// re-read the secret path since Vault's KV v2 engine has no push notification API to watch
+// natively, replaces it with its own interval argument.
+const defaultPollInterval = 30 * time.Second
+
+// ErrSecretNotFound is returned when the configured path has no KV v2 secret data.
+var ErrSecretNotFound = errors.New("vault: secret not found")
+
+// An Option configures provider behaviour.
+type Option interface {
+	apply(*Provider)
+}
+
+// OptionFunc is an adapter allowing regular functions to act as Options.
+type OptionFunc func(*Provider)
+
+func (fn OptionFunc) apply(p *Provider) {
+	fn(p)
+}
+
+// WithSeparator configures the separator used to split a secret's keys into a fido.Path, e.g. a key
+// "database.password" becomes Path{"database", "password"}. Default: "."
+func WithSeparator(sep string) Option {
+	return OptionFunc(func(p *Provider) {
+		p.separator = sep
+	})
+}
+
+// WithWatch enables polling the secret path every interval, re-emitting its values so a secret
+// rotated in Vault flows back into the bound struct via Notify.
+func WithWatch(interval time.Duration) Option {
+	return OptionFunc(func(p *Provider) {
+		p.watch = true
+		p.pollInterval = interval
+	})
+}
+
+// Provider implements a fido.Provider that resolves configuration values from a HashiCorp Vault KV
+// v2 secret.
+type Provider struct {
+	read         func(ctx context.Context, path string) (*vaultapi.Secret, error)
+	path         string
+	separator    string
+	watch        bool
+	pollInterval time.Duration
+	stop         chan struct{}
+}
+
+// New constructs a Provider reading the KV v2 secret at path using the given Vault client. client
+// must already be authenticated, e.g. via client.SetToken for token auth, or by writing to
+// "auth/approle/login" and setting the returned client token for AppRole auth, before it is passed
+// here.
+func New(client *vaultapi.Client, path string, opts ...Option) *Provider {
+	p := &Provider{
+		read:         client.Logical().ReadWithContext,
+		path:         path,
+		separator:    ".",
+		pollInterval: defaultPollInterval,
+		stop:         make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt.apply(p)
+	}
+
+	return p
+}
+
+func (p *Provider) String() string {
+	return ProviderName
+}
+
+// Values reads the KV v2 secret at path and sends each key/value pair in its data map to the
+// writer, splitting the key on separator to form a fido.Path.
+func (p *Provider) Values(ctx context.Context, writer fido.Writer) error {
+	data, err := p.data(ctx)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range data {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			path := fido.Path(strings.Split(k, p.separator))
+
+			if err := writer.Write(path, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// data reads the secret at path and unwraps its KV v2 "data" envelope.
+func (p *Provider) data(ctx context.Context) (map[string]interface{}, error) {
+	secret, err := p.read(ctx, p.path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read vault secret %s", err, p.path)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("%w: %s", ErrSecretNotFound, p.path)
+	}
+
+	data, ok := secret.Data["data"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not a KV v2 secret", ErrSecretNotFound, p.path)
+	}
+
+	return data, nil
+}
+
+// Notify implements the optional fido.NotifyProvider extension interface. When WithWatch is
+// configured it polls the secret path every interval, re-running Values so a rotated secret is
+// picked up; otherwise it returns nil immediately.
+func (p *Provider) Notify(ctx context.Context, writer fido.Writer) error {
+	if !p.watch {
+		return nil
+	}
+
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-p.stop:
+			return nil
+		case <-ticker.C:
+			if err := p.Values(ctx, writer); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Close implements the optional fido.CloseProvider extension interface, stopping Notify's poll loop
+// if WithWatch was configured.
+func (p *Provider) Close() error {
+	close(p.stop)
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package vault_test
+
+import (
+	"context"
+	"fmt"
+
+	vaultapi "github.com/hashicorp/vault/api"
+
+	"github.com/krak3n/fido"
+	"github.com/krak3n/fido/providers/vault"
+)
+
+func Example() {
+	type Config struct {
+		Password string `fido:"password"`
+	}
+
+	var cfg Config
+
+	client, err := vaultapi.NewClient(vaultapi.DefaultConfig())
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	client.SetToken("root")
+
+	f, err := fido.New(&cfg)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	defer f.Close()
+
+	provider := vault.New(client, "secret/data/db")
+
+	if err := f.FetchWithContext(context.Background(), provider); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Printf("%+v", cfg)
+}
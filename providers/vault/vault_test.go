@@ -0,0 +1,192 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+
+	"github.com/krak3n/fido"
+)
+
+func TestProvider_Values(t *testing.T) {
+	type want struct {
+		path  fido.Path
+		value interface{}
+	}
+
+	cases := map[string]struct {
+		ctx  context.Context
+		read func(context.Context, string) (*vaultapi.Secret, error)
+		opts []Option
+		want []want
+		err  error
+	}{
+		"ErrContextCancelled": {
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				return ctx
+			}(),
+			read: func(context.Context, string) (*vaultapi.Secret, error) {
+				return &vaultapi.Secret{
+					Data: map[string]interface{}{
+						"data": map[string]interface{}{"foo.bar": "fizz"},
+					},
+				}, nil
+			},
+			want: []want{},
+			err:  context.Canceled,
+		},
+		"SendsValues": {
+			ctx: context.Background(),
+			read: func(context.Context, string) (*vaultapi.Secret, error) {
+				return &vaultapi.Secret{
+					Data: map[string]interface{}{
+						"data": map[string]interface{}{"foo.bar": "fizz"},
+					},
+				}, nil
+			},
+			want: []want{
+				{path: fido.Path{"foo", "bar"}, value: "fizz"},
+			},
+		},
+		"CustomSeparator": {
+			ctx: context.Background(),
+			read: func(context.Context, string) (*vaultapi.Secret, error) {
+				return &vaultapi.Secret{
+					Data: map[string]interface{}{
+						"data": map[string]interface{}{"foo/bar": "fizz"},
+					},
+				}, nil
+			},
+			opts: []Option{WithSeparator("/")},
+			want: []want{
+				{path: fido.Path{"foo", "bar"}, value: "fizz"},
+			},
+		},
+		"ErrNotKVv2Secret": {
+			ctx: context.Background(),
+			read: func(context.Context, string) (*vaultapi.Secret, error) {
+				return &vaultapi.Secret{Data: map[string]interface{}{"foo": "bar"}}, nil
+			},
+			want: []want{},
+			err:  ErrSecretNotFound,
+		},
+		"ErrSecretMissing": {
+			ctx: context.Background(),
+			read: func(context.Context, string) (*vaultapi.Secret, error) {
+				return nil, nil
+			},
+			want: []want{},
+			err:  ErrSecretNotFound,
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var i int
+
+			writer := fido.WriterFunc(func(p fido.Path, v interface{}) error {
+				if i+1 > len(tc.want) {
+					t.Fatal("received more than expected values")
+				}
+
+				want := tc.want[i]
+
+				if !reflect.DeepEqual(want.path, p) {
+					t.Errorf("want %+v path, got %+v", want.path, p)
+				}
+
+				if !reflect.DeepEqual(want.value, v) {
+					t.Errorf("want %+v value, got %+v", want.value, v)
+				}
+
+				i++
+
+				return nil
+			})
+
+			p := &Provider{read: tc.read, separator: ".", pollInterval: defaultPollInterval}
+			for _, opt := range tc.opts {
+				opt.apply(p)
+			}
+
+			err := p.Values(tc.ctx, writer)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("want %+v error got %+v", tc.err, err)
+			}
+
+			if i != len(tc.want) {
+				t.Errorf("want %d values, got %d", len(tc.want), i)
+			}
+		})
+	}
+}
+
+func TestProvider_Notify(t *testing.T) {
+	t.Run("ReturnsNilWithoutWatch", func(t *testing.T) {
+		t.Parallel()
+
+		p := &Provider{}
+
+		if err := p.Notify(context.Background(), fido.WriterFunc(func(fido.Path, interface{}) error { return nil })); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+	})
+
+	t.Run("PollsUntilClosed", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int32
+
+		p := &Provider{
+			read: func(context.Context, string) (*vaultapi.Secret, error) {
+				atomic.AddInt32(&calls, 1)
+
+				return &vaultapi.Secret{
+					Data: map[string]interface{}{
+						"data": map[string]interface{}{"foo": "bar"},
+					},
+				}, nil
+			},
+			separator:    ".",
+			watch:        true,
+			pollInterval: time.Millisecond,
+			stop:         make(chan struct{}),
+		}
+
+		errCh := make(chan error, 1)
+
+		go func() {
+			errCh <- p.Notify(context.Background(), fido.WriterFunc(func(fido.Path, interface{}) error { return nil }))
+		}()
+
+		for atomic.LoadInt32(&calls) == 0 {
+			time.Sleep(time.Millisecond)
+		}
+
+		if err := p.Close(); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		select {
+		case err := <-errCh:
+			if err != nil {
+				t.Errorf("unexpected error: %+v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for Notify to return after Close")
+		}
+	})
+}
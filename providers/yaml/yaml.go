@@ -0,0 +1,45 @@
+package yaml
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	ghodssyaml "github.com/ghodss/yaml"
+
+	"github.com/krak3n/fido"
+)
+
+// ProviderName is the name of the Provider.
+const ProviderName = "yaml"
+
+// Provider provides a YAML fido.ReadProvider.
+type Provider struct{}
+
+// New constructs a new Provider.
+func New() *Provider {
+	return &Provider{}
+}
+
+func (p *Provider) String() string {
+	return ProviderName
+}
+
+// Values reads YAML from the given io.Reader. ghodss/yaml normalises it to JSON before unmarshalling,
+// so map keys come out as the same map[string]interface{} shape the JSON provider produces, then
+// passes the values back to Fido for processing.
+func (p *Provider) Values(ctx context.Context, reader io.Reader, writer fido.Writer) error {
+	b, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	var dst map[string]interface{}
+
+	if err := ghodssyaml.Unmarshal(b, &dst); err != nil {
+		return fmt.Errorf("%w: failed to unmarshal YAML: '%s'", err, string(b))
+	}
+
+	return fido.WalkMap(ctx, dst, fido.Path{}, writer)
+}
@@ -1,17 +1,55 @@
 package yaml
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
 
-func TestBar(t *testing.T) {
-	t.Parallel()
+	"github.com/krak3n/fido"
+)
 
-	type tc struct {
-		want string
+func TestProvider_Values(t *testing.T) {
+	type want struct {
+		path  fido.Path
+		value interface{}
 	}
 
-	cases := map[string]tc{
-		"ReturnsFoo": {
-			want: "foo",
+	cases := map[string]struct {
+		ctx        context.Context
+		yaml       string
+		want       []want
+		err        error
+		errUnmarshal bool
+	}{
+		"ErrContextCancelled": {
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				return ctx
+			}(),
+			yaml: "foo: bar",
+			err:  context.Canceled,
+		},
+		"InvalidYAML": {
+			ctx:        context.Background(),
+			yaml:       "foo: [bar",
+			errUnmarshal: true,
+		},
+		"SendsValues": {
+			ctx: context.Background(),
+			yaml: `
+foo: bar
+fizz:
+  buzz: bazz
+`,
+			want: []want{
+				{path: fido.Path{"foo"}, value: "bar"},
+				{path: fido.Path{"fizz", "buzz"}, value: "bazz"},
+			},
 		},
 	}
 
@@ -21,10 +59,38 @@ func TestBar(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			got := Bar()
+			var got []want
+
+			writer := fido.WriterFunc(func(p fido.Path, v interface{}) error {
+				got = append(got, want{path: p, value: v})
+
+				return nil
+			})
+
+			err := New().Values(tc.ctx, strings.NewReader(tc.yaml), writer)
+
+			switch {
+			case tc.errUnmarshal:
+				if err == nil || !strings.Contains(err.Error(), "failed to unmarshal YAML") {
+					t.Errorf("want unmarshal error, got %+v", err)
+				}
+			default:
+				if !errors.Is(err, tc.err) {
+					t.Errorf("want %+v error, got %+v", tc.err, err)
+				}
+			}
+
+			byPath := func(s []want) func(i, j int) bool {
+				return func(i, j int) bool {
+					return strings.Join(s[i].path, ".") < strings.Join(s[j].path, ".")
+				}
+			}
+
+			sort.Slice(tc.want, byPath(tc.want))
+			sort.Slice(got, byPath(got))
 
-			if got != tc.want {
-				t.Errorf("want %s, got %s", tc.want, got)
+			if !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("want %+v, got %+v", tc.want, got)
 			}
 		})
 	}
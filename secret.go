@@ -0,0 +1,109 @@
+package fido
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// A SecretResolver resolves a reference or ciphertext value emitted by a Provider — e.g.
+// env:DB_PASSWORD or vault://secret/data/db#password — into its plaintext form before Fido hands
+// the value to field.Stage. Resolvers are tried, in registration order, against every string value a
+// Provider emits; the first one to report it recognises the value wins. A value no resolver
+// recognises is passed through unchanged.
+type SecretResolver interface {
+	Resolve(value string) (plaintext string, handled bool, err error)
+}
+
+// SecretResolverFunc is an adapter allowing regular functions to act as SecretResolvers.
+type SecretResolverFunc func(value string) (string, bool, error)
+
+// Resolve calls the wrapped function implementing the SecretResolver interface.
+func (fn SecretResolverFunc) Resolve(value string) (string, bool, error) {
+	return fn(value)
+}
+
+// WithSecretResolver registers a SecretResolver with Fido. Resolvers are consulted, in
+// registration order, against every string value a Provider gives to the Callback before it is
+// written to its destination field.
+func WithSecretResolver(r SecretResolver) Option {
+	return OptionFunc(func(o *Options) {
+		o.SecretResolvers = append(o.SecretResolvers, r)
+	})
+}
+
+// EnvSecretPrefix is the prefix EnvSecretResolver recognises, e.g. "env:DB_PASSWORD".
+const EnvSecretPrefix = "env:"
+
+// EnvSecretResolver resolves env:<NAME> references via os.Getenv, returning ErrSecretNotFound if
+// the named environment variable is unset so a missing secret surfaces rather than silently
+// resolving to an empty string.
+func EnvSecretResolver() SecretResolver {
+	return SecretResolverFunc(func(value string) (string, bool, error) {
+		name, ok := strings.CutPrefix(value, EnvSecretPrefix)
+		if !ok {
+			return "", false, nil
+		}
+
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			return "", true, fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+		}
+
+		return v, true, nil
+	})
+}
+
+// A SecretKV looks up a secret by path and key, the shape exposed by Vault-style KV secrets
+// engines, allowing NewKVSecretResolver to adapt any such backend into a SecretResolver.
+type SecretKV interface {
+	Get(path, key string) (string, error)
+}
+
+// NewKVSecretResolver builds a SecretResolver that resolves prefix://path#key references, e.g.
+// NewKVSecretResolver("vault", kv) resolves vault://secret/data/db#password against kv.
+func NewKVSecretResolver(prefix string, kv SecretKV) SecretResolver {
+	p := prefix + "://"
+
+	return SecretResolverFunc(func(value string) (string, bool, error) {
+		ref, ok := strings.CutPrefix(value, p)
+		if !ok {
+			return "", false, nil
+		}
+
+		path, key, ok := strings.Cut(ref, "#")
+		if !ok {
+			return "", true, fmt.Errorf("%w: %s is missing a #key", ErrSecretSyntax, value)
+		}
+
+		v, err := kv.Get(path, key)
+		if err != nil {
+			return "", true, fmt.Errorf("%w: %s", ErrSecretNotFound, err)
+		}
+
+		return v, true, nil
+	})
+}
+
+// resolveSecret runs value through resolvers, in order, returning the plaintext produced by the
+// first one that recognises it. Non-string values, and strings no resolver recognises, are
+// returned unchanged.
+func resolveSecret(resolvers []SecretResolver, value interface{}) (interface{}, error) {
+	s, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+
+	for _, r := range resolvers {
+		plaintext, handled, err := r.Resolve(s)
+		if err != nil {
+			return nil, err
+		}
+
+		if handled {
+			return plaintext, nil
+		}
+	}
+
+	return value, nil
+}
@@ -0,0 +1,178 @@
+package fido
+
+import (
+	"errors"
+	"testing"
+)
+
+type mapSecretKV map[string]map[string]string
+
+func (kv mapSecretKV) Get(path, key string) (string, error) {
+	m, ok := kv[path]
+	if !ok {
+		return "", errors.New("path not found")
+	}
+
+	v, ok := m[key]
+	if !ok {
+		return "", errors.New("key not found")
+	}
+
+	return v, nil
+}
+
+func Test_EnvSecretResolver(t *testing.T) {
+	cases := map[string]struct {
+		value   string
+		env     map[string]string
+		want    string
+		handled bool
+		err     error
+	}{
+		"NotRecognised": {
+			value: "plain-value",
+		},
+		"Resolves": {
+			value:   "env:DB_PASSWORD",
+			env:     map[string]string{"DB_PASSWORD": "hunter2"},
+			want:    "hunter2",
+			handled: true,
+		},
+		"MissingEnvVar": {
+			value:   "env:DB_PASSWORD",
+			handled: true,
+			err:     ErrSecretNotFound,
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			got, handled, err := EnvSecretResolver().Resolve(tc.value)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("want error %+v, got %+v", tc.err, err)
+			}
+
+			if handled != tc.handled {
+				t.Errorf("want handled %v, got %v", tc.handled, handled)
+			}
+
+			if tc.handled && tc.err == nil && got != tc.want {
+				t.Errorf("want %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func Test_NewKVSecretResolver(t *testing.T) {
+	kv := mapSecretKV{
+		"secret/data/db": {"password": "s3cret"},
+	}
+
+	cases := map[string]struct {
+		value   string
+		want    string
+		handled bool
+		err     error
+	}{
+		"NotRecognised": {
+			value: "plain-value",
+		},
+		"Resolves": {
+			value:   "vault://secret/data/db#password",
+			want:    "s3cret",
+			handled: true,
+		},
+		"MissingKeySeparator": {
+			value:   "vault://secret/data/db",
+			handled: true,
+			err:     ErrSecretSyntax,
+		},
+		"NotFoundInKV": {
+			value:   "vault://secret/data/missing#password",
+			handled: true,
+			err:     ErrSecretNotFound,
+		},
+	}
+
+	resolver := NewKVSecretResolver("vault", kv)
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, handled, err := resolver.Resolve(tc.value)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("want error %+v, got %+v", tc.err, err)
+			}
+
+			if handled != tc.handled {
+				t.Errorf("want handled %v, got %v", tc.handled, handled)
+			}
+
+			if tc.handled && tc.err == nil && got != tc.want {
+				t.Errorf("want %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func Test_resolveSecret(t *testing.T) {
+	cases := map[string]struct {
+		resolvers []SecretResolver
+		value     interface{}
+		want      interface{}
+		err       error
+	}{
+		"NonStringPassedThrough": {
+			value: 42,
+			want:  42,
+		},
+		"NoResolversPassedThrough": {
+			value: "env:FOO",
+			want:  "env:FOO",
+		},
+		"FirstHandlerWins": {
+			resolvers: []SecretResolver{
+				SecretResolverFunc(func(string) (string, bool, error) { return "", false, nil }),
+				SecretResolverFunc(func(string) (string, bool, error) { return "resolved", true, nil }),
+			},
+			value: "ref",
+			want:  "resolved",
+		},
+		"ResolverError": {
+			resolvers: []SecretResolver{
+				SecretResolverFunc(func(string) (string, bool, error) { return "", true, ErrSecretNotFound }),
+			},
+			value: "ref",
+			err:   ErrSecretNotFound,
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := resolveSecret(tc.resolvers, tc.value)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("want error %+v, got %+v", tc.err, err)
+			}
+
+			if tc.err == nil && got != tc.want {
+				t.Errorf("want %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
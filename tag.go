@@ -11,6 +11,43 @@ type Tag struct {
 	RawTag    string
 	Name      string
 	FieldName string
+	// Merge is the merge=<strategy> tag option, e.g. "append" or "deep", overriding the Fido level
+	// MergePolicy for this field. Empty if not given.
+	Merge string
+	// Unique is the unique tag option, only meaningful alongside Merge == "append".
+	Unique bool
+	// Default is the default=<value> tag option, applied by Fido once every provider has run if the
+	// field is still unset. HasDefault reports whether one was given at all, since the empty string
+	// is itself a valid default.
+	Default    string
+	HasDefault bool
+	// Required is the required tag option. Fetch returns ErrRequiredFieldMissing for this field if
+	// no provider, and no default, ever set a value for it.
+	Required bool
+	// Validate holds the raw argument of each validate=<name>[=<arg>] tag option given, in order,
+	// e.g. validate=min=1 contributes "min=1". Run against the field's final value after Fetch.
+	Validate []string
+	// Env is the env=<NAME> tag option, naming the environment variable Fido's built-in env
+	// provider looks up for this field in preference to any path based name mapping. Empty if not
+	// given.
+	Env string
+	// Aliases holds each alias=<name> tag option given, in order, letting a field accept a value
+	// written to any of these alternate paths in addition to Name, e.g. so a YAML provider using
+	// "http_port" and an env provider using "port" can both set the same field.
+	Aliases []string
+	// Transform holds the raw argument of each transform=<name> tag option given, in order, e.g.
+	// transform=trim contributes "trim". Each name is looked up against the Transforms registered
+	// with WithTransform and run, in order, against a value a Provider gives Fido before it is
+	// staged onto this field.
+	Transform []string
+	// Secret is the secret tag option, marking this field's value as sensitive so it is redacted,
+	// e.g. to "***", everywhere Fido would otherwise surface it verbatim, such as a FieldUpdate's
+	// String() output.
+	Secret bool
+	// Separator is the sep=<char> tag option, overriding pathSeparator for this field alone when
+	// its Path is rendered back to a string, complementing what inmemory.WithSeparator does
+	// globally for an entire provider. Empty if not given, meaning the default "." applies.
+	Separator string
 }
 
 func (t Tag) String() string {
@@ -31,9 +68,30 @@ func LookupTag(tag string, f reflect.StructField) (Tag, error) {
 	t.RawTag = v
 
 	for i, v := range strings.Split(v, ",") {
-		switch i {
-		case 0:
+		switch {
+		case i == 0:
 			t.Name = v
+		case strings.HasPrefix(v, "merge="):
+			t.Merge = strings.TrimPrefix(v, "merge=")
+		case v == "unique":
+			t.Unique = true
+		case strings.HasPrefix(v, "default="):
+			t.Default = strings.TrimPrefix(v, "default=")
+			t.HasDefault = true
+		case v == "required":
+			t.Required = true
+		case strings.HasPrefix(v, "validate="):
+			t.Validate = append(t.Validate, strings.TrimPrefix(v, "validate="))
+		case strings.HasPrefix(v, "env="):
+			t.Env = strings.TrimPrefix(v, "env=")
+		case strings.HasPrefix(v, "alias="):
+			t.Aliases = append(t.Aliases, strings.TrimPrefix(v, "alias="))
+		case strings.HasPrefix(v, "transform="):
+			t.Transform = append(t.Transform, strings.TrimPrefix(v, "transform="))
+		case v == "secret":
+			t.Secret = true
+		case strings.HasPrefix(v, "sep="):
+			t.Separator = strings.TrimPrefix(v, "sep=")
 		}
 	}
 
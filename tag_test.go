@@ -35,6 +35,76 @@ func TestLookupTag(t *testing.T) {
 				Name:      "foo",
 			},
 		},
+		"ExtractsDefaultRequiredAndValidate": {
+			tag: DefaultStructTag,
+			field: reflect.StructField{
+				Name: "Port",
+				Tag:  reflect.StructTag(`fido:"database.port,default=5432,required,validate=min=1,validate=max=65535"`),
+			},
+			want: Tag{
+				FieldName:  "Port",
+				RawTag:     `database.port,default=5432,required,validate=min=1,validate=max=65535`,
+				Name:       "database.port",
+				Default:    "5432",
+				HasDefault: true,
+				Required:   true,
+				Validate:   []string{"min=1", "max=65535"},
+			},
+		},
+		"ExtractsEnvAndAlias": {
+			tag: DefaultStructTag,
+			field: reflect.StructField{
+				Name: "Port",
+				Tag:  reflect.StructTag(`fido:"port,env=PORT,alias=http_port,alias=httpPort"`),
+			},
+			want: Tag{
+				FieldName: "Port",
+				RawTag:    `port,env=PORT,alias=http_port,alias=httpPort`,
+				Name:      "port",
+				Env:       "PORT",
+				Aliases:   []string{"http_port", "httpPort"},
+			},
+		},
+		"ExtractsTransform": {
+			tag: DefaultStructTag,
+			field: reflect.StructField{
+				Name: "Name",
+				Tag:  reflect.StructTag(`fido:"name,transform=trim,transform=lower"`),
+			},
+			want: Tag{
+				FieldName: "Name",
+				RawTag:    `name,transform=trim,transform=lower`,
+				Name:      "name",
+				Transform: []string{"trim", "lower"},
+			},
+		},
+		"ExtractsSecretAndSeparator": {
+			tag: DefaultStructTag,
+			field: reflect.StructField{
+				Name: "Password",
+				Tag:  reflect.StructTag(`fido:"database/password,secret,sep=/"`),
+			},
+			want: Tag{
+				FieldName: "Password",
+				RawTag:    `database/password,secret,sep=/`,
+				Name:      "database/password",
+				Secret:    true,
+				Separator: "/",
+			},
+		},
+		"EmptyDefaultStillHasDefault": {
+			tag: DefaultStructTag,
+			field: reflect.StructField{
+				Name: "Foo",
+				Tag:  reflect.StructTag(`fido:"foo,default="`),
+			},
+			want: Tag{
+				FieldName:  "Foo",
+				RawTag:     `foo,default=`,
+				Name:       "foo",
+				HasDefault: true,
+			},
+		},
 	}
 
 	for name, testCase := range cases {
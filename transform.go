@@ -0,0 +1,63 @@
+package fido
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// A Transform converts a value a Provider has given Fido into the value actually staged onto the
+// destination field, or rejects it outright by returning a non-nil error, e.g. trimming whitespace
+// or enforcing that a string matches one of a fixed set of alternatives. Register one with
+// WithTransform under the name a field's transform= tag option, or TransformStructTag, references.
+type Transform func(interface{}) (interface{}, error)
+
+// TransformStructTag is the struct tag LookupTransformTag parses for field-level value transforms,
+// independent of the DefaultStructTag, e.g. `transform:"trim,lower"`. It is merged with any
+// transform= options given in the fido struct tag.
+const TransformStructTag = "transform"
+
+// LookupTransformTag parses the TransformStructTag on f, a comma-separated list of Transform
+// names, each run, in order, against the field's value before it is staged. ok reports whether the
+// field carried the tag at all.
+func LookupTransformTag(f reflect.StructField) (names []string, ok bool) {
+	raw, has := f.Tag.Lookup(TransformStructTag)
+	if !has {
+		return nil, false
+	}
+
+	for _, v := range strings.Split(raw, ",") {
+		if v != "" {
+			names = append(names, v)
+		}
+	}
+
+	return names, true
+}
+
+// transformValue runs to through fld's transform= names, in order, against the Transforms
+// registered on f with WithTransform, returning a wrapped ErrSetInvalidValue if a name has no
+// Transform registered or the Transform itself rejects the value. Shared by callback, which runs
+// it against every value a Provider gives Fido before staging it, and transformMiddleware.
+func (f *Fido) transformValue(fld Field, path Path, to interface{}) (interface{}, error) {
+	tf, ok := fld.(taggedField)
+	if !ok {
+		return to, nil
+	}
+
+	for _, name := range tf.transformNames() {
+		fn, ok := f.options.Transforms[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s has no transform registered for %s", ErrSetInvalidValue, path, name)
+		}
+
+		v, err := fn(to)
+		if err != nil {
+			return nil, fmt.Errorf("%w: transform %q rejected %s: %s", ErrSetInvalidValue, name, path, err)
+		}
+
+		to = v
+	}
+
+	return to, nil
+}
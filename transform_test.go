@@ -0,0 +1,118 @@
+package fido
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_LookupTransformTag(t *testing.T) {
+	cases := map[string]struct {
+		field     reflect.StructField
+		wantNames []string
+		wantOK    bool
+	}{
+		"NoTag": {
+			field: reflect.StructField{Name: "Foo"},
+		},
+		"SingleName": {
+			field: reflect.StructField{
+				Name: "Name",
+				Tag:  reflect.StructTag(`transform:"trim"`),
+			},
+			wantNames: []string{"trim"},
+			wantOK:    true,
+		},
+		"MultipleNames": {
+			field: reflect.StructField{
+				Name: "Name",
+				Tag:  reflect.StructTag(`transform:"trim,lower"`),
+			},
+			wantNames: []string{"trim", "lower"},
+			wantOK:    true,
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			names, ok := LookupTransformTag(tc.field)
+
+			if ok != tc.wantOK {
+				t.Errorf("want ok %v, got %v", tc.wantOK, ok)
+			}
+
+			if !reflect.DeepEqual(tc.wantNames, names) {
+				t.Errorf("want names %+v, got %+v", tc.wantNames, names)
+			}
+		})
+	}
+}
+
+func Test_Fido_transformValue(t *testing.T) {
+	upper := Transform(func(v interface{}) (interface{}, error) {
+		s, _ := v.(string)
+
+		return s + "!", nil
+	})
+
+	cases := map[string]struct {
+		fld  Field
+		to   interface{}
+		want interface{}
+		err  error
+	}{
+		"NoTransformsRunsValueThrough": {
+			fld:  &field{path: Path{"foo"}},
+			to:   "bar",
+			want: "bar",
+		},
+		"RegisteredTransformApplied": {
+			fld:  &field{path: Path{"foo"}, transforms: []string{"upper"}},
+			to:   "bar",
+			want: "bar!",
+		},
+		"UnregisteredTransformErrors": {
+			fld: &field{path: Path{"foo"}, transforms: []string{"missing"}},
+			to:  "bar",
+			err: ErrSetInvalidValue,
+		},
+		"TransformErrorWrapsErrSetInvalidValue": {
+			fld: &field{path: Path{"foo"}, transforms: []string{"fails"}},
+			to:  "bar",
+			err: ErrSetInvalidValue,
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			f := &Fido{
+				options: Options{
+					Transforms: map[string]Transform{
+						"upper": upper,
+						"fails": func(interface{}) (interface{}, error) {
+							return nil, errors.New("always fails")
+						},
+					},
+				},
+			}
+
+			got, err := f.transformValue(tc.fld, tc.fld.Path(), tc.to)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("want error %+v, got %+v", tc.err, err)
+			}
+
+			if tc.err == nil && !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("want %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package fido
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// taggedField is implemented by field, and promoted through mapfield's embedded *field, exposing
+// the default=, required, validate=, env=, transform= and secret struct tag options to the
+// post-Fetch validation pass, Fido's built-in env provider and the transform pipeline.
+type taggedField interface {
+	requiredField() bool
+	defaultValue() (string, bool)
+	validateRules() []string
+	envHint() (string, bool)
+	transformNames() []string
+	secretField() bool
+}
+
+// A Validator is implemented by a destination struct, or any nested struct field, that wants to
+// run its own cross-field checks after Fido has finished hydrating it from the configured
+// Providers, e.g. "EndDate must be after StartDate". Fido.Validate invokes it on every struct
+// encountered while walking the destination, addressed via its pointer receiver.
+type Validator interface {
+	Validate() error
+}
+
+// ValidateStructTag is the struct tag LookupValidateTag parses for field-level validation rules,
+// independent of the DefaultStructTag, e.g. `validate:"required,min=1,max=65535"`. It is merged
+// with any validate= options given in the fido struct tag.
+const ValidateStructTag = "validate"
+
+// LookupValidateTag parses the ValidateStructTag on f, a comma-separated list of the same
+// "required", "min=", "max=", "oneof=" and "regex=" (also accepted spelled "regexp=") options
+// recognised by the fido struct tag's validate= option, expressed in its own idiomatic tag the way
+// go-playground/validator and similar libraries do. ok reports whether the field carried the tag
+// at all.
+func LookupValidateTag(f reflect.StructField) (required bool, rules []string, ok bool) {
+	raw, has := f.Tag.Lookup(ValidateStructTag)
+	if !has {
+		return false, nil, false
+	}
+
+	for _, v := range strings.Split(raw, ",") {
+		switch {
+		case v == "required":
+			required = true
+		case strings.HasPrefix(v, "regexp="):
+			rules = append(rules, "regex="+strings.TrimPrefix(v, "regexp="))
+		case v != "":
+			rules = append(rules, v)
+		}
+	}
+
+	return required, rules, true
+}
+
+// ValidatorFunc validates a field's final value against the raw argument of a validate=name=arg
+// tag option, e.g. the "1" in validate=min=1, returning a non-nil error if the value is invalid.
+type ValidatorFunc func(value reflect.Value, arg string) error
+
+var (
+	validatorsMu sync.RWMutex
+	validators   = map[string]ValidatorFunc{
+		"min":   validateMin,
+		"max":   validateMax,
+		"oneof": validateOneOf,
+		"regex": validateRegex,
+	}
+)
+
+// RegisterValidator registers a named ValidatorFunc for use with the validate=name=arg struct tag
+// option, e.g. RegisterValidator("uuid", validateUUID) enables fido:"id,validate=uuid=<arg>".
+// Registering a name already in use, including a built-in one, overwrites it.
+func RegisterValidator(name string, fn ValidatorFunc) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+
+	validators[name] = fn
+}
+
+func validator(name string) (ValidatorFunc, bool) {
+	validatorsMu.RLock()
+	defer validatorsMu.RUnlock()
+
+	fn, ok := validators[name]
+
+	return fn, ok
+}
+
+// validateMin requires value to be numeric and no less than arg.
+func validateMin(value reflect.Value, arg string) error {
+	min, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid min argument %q", ErrValidation, arg)
+	}
+
+	n, ok := toFloat(value)
+	if !ok {
+		return fmt.Errorf("%w: min is not supported for %s", ErrValidation, value.Kind())
+	}
+
+	if n < min {
+		return fmt.Errorf("%w: %v is less than minimum %v", ErrValidation, value.Interface(), min)
+	}
+
+	return nil
+}
+
+// validateMax requires value to be numeric and no greater than arg.
+func validateMax(value reflect.Value, arg string) error {
+	max, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid max argument %q", ErrValidation, arg)
+	}
+
+	n, ok := toFloat(value)
+	if !ok {
+		return fmt.Errorf("%w: max is not supported for %s", ErrValidation, value.Kind())
+	}
+
+	if n > max {
+		return fmt.Errorf("%w: %v is greater than maximum %v", ErrValidation, value.Interface(), max)
+	}
+
+	return nil
+}
+
+// validateOneOf requires value's string representation to equal one of arg's pipe separated
+// alternatives, e.g. oneof=dev|stage|prod.
+func validateOneOf(value reflect.Value, arg string) error {
+	s := fmt.Sprintf("%v", value.Interface())
+
+	for _, want := range strings.Split(arg, "|") {
+		if s == want {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %v is not one of %s", ErrValidation, value.Interface(), arg)
+}
+
+// validateRegex requires value's string representation to match the arg regular expression.
+func validateRegex(value reflect.Value, arg string) error {
+	re, err := regexp.Compile(arg)
+	if err != nil {
+		return fmt.Errorf("%w: invalid regex %q", ErrValidation, arg)
+	}
+
+	s := fmt.Sprintf("%v", value.Interface())
+
+	if !re.MatchString(s) {
+		return fmt.Errorf("%w: %v does not match %s", ErrValidation, value.Interface(), arg)
+	}
+
+	return nil
+}
+
+// validateFieldRules runs fld's validate= rules, if it implements taggedField, against its current
+// value, returning a wrapped ErrValidation naming fld's Path on the first rule with no registered
+// validator or whose ValidatorFunc rejects the value. Shared by Fido.validate, which runs it across
+// every field once a Fetch completes, and Fido.commit, which runs it against a single field right
+// after committing it so a bad reload is reverted before any later field in the same batch is
+// committed.
+func validateFieldRules(fld Field) error {
+	tf, ok := fld.(taggedField)
+	if !ok {
+		return nil
+	}
+
+	for _, rule := range tf.validateRules() {
+		name, arg := rule, ""
+		if i := strings.Index(rule, "="); i >= 0 {
+			name, arg = rule[:i], rule[i+1:]
+		}
+
+		fn, ok := validator(name)
+		if !ok {
+			return fmt.Errorf("%w: %s has no validator registered for %s", ErrValidation, fld.Path(), name)
+		}
+
+		if err := fn(fld.Value(), arg); err != nil {
+			return fmt.Errorf("%w: %s", err, fld.Path())
+		}
+	}
+
+	return nil
+}
+
+// toFloat converts value's underlying numeric Kind to a float64 for comparison by min and max.
+func toFloat(value reflect.Value) (float64, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,406 @@
+package fido
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_LookupValidateTag(t *testing.T) {
+	cases := map[string]struct {
+		field        reflect.StructField
+		wantRequired bool
+		wantRules    []string
+		wantOK       bool
+	}{
+		"NoTag": {
+			field: reflect.StructField{Name: "Foo"},
+		},
+		"RequiredAndRules": {
+			field: reflect.StructField{
+				Name: "Port",
+				Tag:  reflect.StructTag(`validate:"required,min=1,max=65535,oneof=80|443"`),
+			},
+			wantRequired: true,
+			wantRules:    []string{"min=1", "max=65535", "oneof=80|443"},
+			wantOK:       true,
+		},
+		"RegexpSpellingAliasesRegex": {
+			field: reflect.StructField{
+				Name: "Name",
+				Tag:  reflect.StructTag(`validate:"regexp=^[a-z]+$"`),
+			},
+			wantRules: []string{"regex=^[a-z]+$"},
+			wantOK:    true,
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			required, rules, ok := LookupValidateTag(tc.field)
+
+			if ok != tc.wantOK {
+				t.Errorf("want ok %v, got %v", tc.wantOK, ok)
+			}
+
+			if required != tc.wantRequired {
+				t.Errorf("want required %v, got %v", tc.wantRequired, required)
+			}
+
+			if !reflect.DeepEqual(tc.wantRules, rules) {
+				t.Errorf("want rules %+v, got %+v", tc.wantRules, rules)
+			}
+		})
+	}
+}
+
+type validatingStruct struct {
+	err error
+}
+
+func (v *validatingStruct) Validate() error {
+	return v.err
+}
+
+func Test_Fido_validateStructs(t *testing.T) {
+	cases := map[string]struct {
+		target *validatingStruct
+		err    error
+	}{
+		"Passes": {
+			target: &validatingStruct{},
+		},
+		"FailsWrapsErrValidation": {
+			target: &validatingStruct{err: errors.New("boom")},
+			err:    ErrValidation,
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			f := &Fido{structs: []reflect.Value{reflect.ValueOf(tc.target).Elem()}}
+
+			err := f.validateStructs()
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("want error %+v, got %+v", tc.err, err)
+			}
+		})
+	}
+}
+
+func Test_validateMin(t *testing.T) {
+	cases := map[string]struct {
+		value reflect.Value
+		arg   string
+		err   error
+	}{
+		"PassesAtBoundary": {
+			value: reflect.ValueOf(1),
+			arg:   "1",
+		},
+		"FailsBelowMinimum": {
+			value: reflect.ValueOf(0),
+			arg:   "1",
+			err:   ErrValidation,
+		},
+		"InvalidArgument": {
+			value: reflect.ValueOf(1),
+			arg:   "not-a-number",
+			err:   ErrValidation,
+		},
+		"UnsupportedKind": {
+			value: reflect.ValueOf("1"),
+			arg:   "1",
+			err:   ErrValidation,
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateMin(tc.value, tc.arg)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("want error %+v, got %+v", tc.err, err)
+			}
+		})
+	}
+}
+
+func Test_validateMax(t *testing.T) {
+	cases := map[string]struct {
+		value reflect.Value
+		arg   string
+		err   error
+	}{
+		"PassesAtBoundary": {
+			value: reflect.ValueOf(65535),
+			arg:   "65535",
+		},
+		"FailsAboveMaximum": {
+			value: reflect.ValueOf(65536),
+			arg:   "65535",
+			err:   ErrValidation,
+		},
+		"InvalidArgument": {
+			value: reflect.ValueOf(1),
+			arg:   "not-a-number",
+			err:   ErrValidation,
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateMax(tc.value, tc.arg)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("want error %+v, got %+v", tc.err, err)
+			}
+		})
+	}
+}
+
+func Test_validateOneOf(t *testing.T) {
+	cases := map[string]struct {
+		value reflect.Value
+		arg   string
+		err   error
+	}{
+		"Matches": {
+			value: reflect.ValueOf("stage"),
+			arg:   "dev|stage|prod",
+		},
+		"NoMatch": {
+			value: reflect.ValueOf("qa"),
+			arg:   "dev|stage|prod",
+			err:   ErrValidation,
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateOneOf(tc.value, tc.arg)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("want error %+v, got %+v", tc.err, err)
+			}
+		})
+	}
+}
+
+func Test_validateRegex(t *testing.T) {
+	cases := map[string]struct {
+		value reflect.Value
+		arg   string
+		err   error
+	}{
+		"Matches": {
+			value: reflect.ValueOf("abc"),
+			arg:   "^[a-z]+$",
+		},
+		"NoMatch": {
+			value: reflect.ValueOf("ABC"),
+			arg:   "^[a-z]+$",
+			err:   ErrValidation,
+		},
+		"InvalidPattern": {
+			value: reflect.ValueOf("abc"),
+			arg:   "(",
+			err:   ErrValidation,
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateRegex(tc.value, tc.arg)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("want error %+v, got %+v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestRegisterValidator(t *testing.T) {
+	RegisterValidator("always-fail", func(reflect.Value, string) error {
+		return errors.New("always fails")
+	})
+
+	fn, ok := validator("always-fail")
+	if !ok {
+		t.Fatal("want always-fail to be registered")
+	}
+
+	if err := fn(reflect.ValueOf("x"), ""); err == nil {
+		t.Error("want error, got nil")
+	}
+}
+
+type stubProvider struct{}
+
+func (stubProvider) String() string { return "stub" }
+
+func (stubProvider) Values(ctx context.Context, writer Writer) error { return nil }
+
+func Test_Fido_validate(t *testing.T) {
+	cases := map[string]struct {
+		build func() (*Fido, reflect.Value)
+		want  interface{}
+		err   error
+	}{
+		"RequiredFieldMissingErrors": {
+			build: func() (*Fido, reflect.Value) {
+				v := reflect.New(reflect.TypeOf("")).Elem()
+
+				f := &Fido{fields: fields{}}
+				f.fields.set(Path{"foo"}, &field{path: Path{"foo"}, value: v, required: true})
+
+				return f, v
+			},
+			err: ErrRequiredFieldMissing,
+		},
+		"DefaultAppliedWhenUnset": {
+			build: func() (*Fido, reflect.Value) {
+				v := reflect.New(reflect.TypeOf("")).Elem()
+
+				f := &Fido{fields: fields{}}
+				f.fields.set(Path{"foo"}, &field{path: Path{"foo"}, value: v, def: "bar", hasDefault: true})
+
+				return f, v
+			},
+			want: "bar",
+		},
+		"DefaultSkippedWhenProviderSet": {
+			build: func() (*Fido, reflect.Value) {
+				v := reflect.New(reflect.TypeOf("")).Elem()
+				v.SetString("set")
+
+				f := &Fido{fields: fields{}}
+				f.fields.set(Path{"foo"}, &field{path: Path{"foo"}, value: v, provider: stubProvider{}, def: "bar", hasDefault: true})
+
+				return f, v
+			},
+			want: "set",
+		},
+		"DefaultWinsWhenAlsoRequired": {
+			build: func() (*Fido, reflect.Value) {
+				v := reflect.New(reflect.TypeOf("")).Elem()
+
+				f := &Fido{fields: fields{}}
+				f.fields.set(Path{"foo"}, &field{path: Path{"foo"}, value: v, required: true, def: "bar", hasDefault: true})
+
+				return f, v
+			},
+			want: "bar",
+		},
+		"ValidateRulePasses": {
+			build: func() (*Fido, reflect.Value) {
+				v := reflect.New(reflect.TypeOf(0)).Elem()
+				v.SetInt(8080)
+
+				f := &Fido{fields: fields{}}
+				f.fields.set(Path{"port"}, &field{path: Path{"port"}, value: v, provider: stubProvider{}, validates: []string{"min=1", "max=65535"}})
+
+				return f, v
+			},
+			want: 8080,
+		},
+		"ValidateRuleFails": {
+			build: func() (*Fido, reflect.Value) {
+				v := reflect.New(reflect.TypeOf(0)).Elem()
+				v.SetInt(-1)
+
+				f := &Fido{fields: fields{}}
+				f.fields.set(Path{"port"}, &field{path: Path{"port"}, value: v, provider: stubProvider{}, validates: []string{"min=1"}})
+
+				return f, v
+			},
+			err: ErrValidation,
+		},
+		"UnknownValidatorErrors": {
+			build: func() (*Fido, reflect.Value) {
+				v := reflect.New(reflect.TypeOf("")).Elem()
+
+				f := &Fido{fields: fields{}}
+				f.fields.set(Path{"foo"}, &field{path: Path{"foo"}, value: v, provider: stubProvider{}, validates: []string{"bogus"}})
+
+				return f, v
+			},
+			err: ErrValidation,
+		},
+		"ValidateSkippedWhenUnsetNotRequiredNoDefault": {
+			build: func() (*Fido, reflect.Value) {
+				v := reflect.New(reflect.TypeOf(0)).Elem()
+
+				f := &Fido{fields: fields{}}
+				f.fields.set(Path{"port"}, &field{path: Path{"port"}, value: v, validates: []string{"min=1"}})
+
+				return f, v
+			},
+			want: 0,
+		},
+	}
+
+	for name, testCase := range cases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			f, v := tc.build()
+
+			err := f.validate()
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("want error %+v, got %+v", tc.err, err)
+			}
+
+			if tc.want != nil && !reflect.DeepEqual(tc.want, v.Interface()) {
+				t.Errorf("want %+v, got %+v", tc.want, v.Interface())
+			}
+		})
+	}
+}
+
+// Test_Fido_validate_AggregatesRequiredErrors asserts that every required-but-unset field is
+// reported together, via errors.Join, rather than validate returning on the first one it finds.
+func Test_Fido_validate_AggregatesRequiredErrors(t *testing.T) {
+	foo := reflect.New(reflect.TypeOf("")).Elem()
+	bar := reflect.New(reflect.TypeOf("")).Elem()
+
+	f := &Fido{fields: fields{}}
+	f.fields.set(Path{"foo"}, &field{path: Path{"foo"}, value: foo, required: true})
+	f.fields.set(Path{"bar"}, &field{path: Path{"bar"}, value: bar, required: true})
+
+	err := f.validate()
+
+	if !errors.Is(err, ErrRequiredFieldMissing) {
+		t.Fatalf("want ErrRequiredFieldMissing, got %+v", err)
+	}
+
+	if !strings.Contains(err.Error(), "foo") || !strings.Contains(err.Error(), "bar") {
+		t.Errorf("want both foo and bar named in %q", err.Error())
+	}
+}
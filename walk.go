@@ -5,6 +5,15 @@ import (
 	"reflect"
 )
 
+// A MapWriter is an optional extension interface a Writer can implement to receive a nested map
+// value as a single write, rather than having WalkMap flatten it into individual leaf writes. This
+// lets a Writer backing a MergeDeepMap field merge the whole object in one step instead of one key
+// at a time. WriteMap returns false to tell WalkMap the value was not handled and should be
+// flattened as normal.
+type MapWriter interface {
+	WriteMap(path Path, value map[string]interface{}) (bool, error)
+}
+
 // WalkMap traverses the given map calling the provided callback function.
 func WalkMap(ctx context.Context, src map[string]interface{}, path Path, writer Writer) error {
 	for key, value := range src {
@@ -15,7 +24,21 @@ func WalkMap(ctx context.Context, src map[string]interface{}, path Path, writer
 			rv := reflect.ValueOf(value)
 
 			if rv.Kind() == reflect.Map {
-				if err := WalkMap(ctx, value.(map[string]interface{}), append(path, key), writer); err != nil {
+				m := value.(map[string]interface{})
+				p := append(path, key)
+
+				if mw, ok := writer.(MapWriter); ok {
+					handled, err := mw.WriteMap(p, m)
+					if err != nil {
+						return err
+					}
+
+					if handled {
+						continue
+					}
+				}
+
+				if err := WalkMap(ctx, m, p, writer); err != nil {
 					return err
 				}
 
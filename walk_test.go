@@ -17,7 +17,7 @@ func TestWalkMap(t *testing.T) {
 
 	cases := map[string]struct {
 		ctx      context.Context
-		callback func(*testing.T, map[string]interface{}) Callback
+		callback func(*testing.T, map[string]interface{}) Writer
 		want     map[string]interface{}
 		err      error
 	}{
@@ -28,19 +28,19 @@ func TestWalkMap(t *testing.T) {
 
 				return ctx
 			}(),
-			callback: func(t *testing.T, _ map[string]interface{}) Callback {
-				return func(Path, interface{}) error {
+			callback: func(t *testing.T, _ map[string]interface{}) Writer {
+				return WriterFunc(func(Path, interface{}) error {
 					return nil
-				}
+				})
 			},
 			want: map[string]interface{}{},
 			err:  context.Canceled,
 		},
 		"CallbackError": {
 			ctx: context.Background(),
-			callback: func(t *testing.T, _ map[string]interface{}) Callback {
+			callback: func(t *testing.T, _ map[string]interface{}) Writer {
 				var i int
-				return func(Path, interface{}) error {
+				return WriterFunc(func(Path, interface{}) error {
 					defer func() {
 						i++
 					}()
@@ -53,19 +53,19 @@ func TestWalkMap(t *testing.T) {
 					}
 
 					return nil
-				}
+				})
 			},
 			want: map[string]interface{}{},
 			err:  ErrSetInvalidValue,
 		},
 		"PassesValuesToCallback": {
 			ctx: context.Background(),
-			callback: func(t *testing.T, m map[string]interface{}) Callback {
-				return func(path Path, value interface{}) error {
+			callback: func(t *testing.T, m map[string]interface{}) Writer {
+				return WriterFunc(func(path Path, value interface{}) error {
 					m[path.key()] = value
 
 					return nil
-				}
+				})
 			},
 			want: map[string]interface{}{
 				"foo":       "bar",
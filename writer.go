@@ -22,18 +22,25 @@ func (fn WriterFunc) Write(path Path, value interface{}) error {
 // WriterMiddleware is a function that allows Writers to be wrapped with other Writers.
 type WriterMiddleware func(Writer) Writer
 
-// WrapWriter wraps a Writer with the provided writer middleware functions.
+// WrapWriter wraps writer with the given middlewares, in registration order, so the first
+// middleware given is the outermost: the first to see a Write and the last to see it return.
 func WrapWriter(writer Writer, middlewares ...WriterMiddleware) Writer {
-	return WriterFunc(func(path Path, value interface{}) error {
-		for i := len(middlewares) - 1; i >= 0; i-- {
-			writer = middlewares[i](writer)
-		}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		writer = middlewares[i](writer)
+	}
 
-		return writer.Write(path, value)
-	})
+	return writer
 }
 
+// writer returns the terminal Writer for a single Provider invocation, writing each value straight
+// to its destination field as it arrives. When Options.AtomicFetch is enabled it instead returns an
+// atomicWriter, which stages every value into a batch for Flush and Rollback to apply or discard as
+// one unit.
 func (f *Fido) writer(ctx context.Context, provider Provider) Writer {
+	if f.options.AtomicFetch {
+		return newAtomicWriter(f, provider)
+	}
+
 	return WriterFunc(func(path Path, value interface{}) error {
 		field, ok := f.fields.get(path)
 		if !ok {
@@ -42,18 +49,83 @@ func (f *Fido) writer(ctx context.Context, provider Provider) Writer {
 
 		current := field.Value().Interface()
 
-		if value != current {
+		if reflect.DeepEqual(value, current) {
 			return nil
 		}
 
 		if err := field.Set(value, provider); err != nil {
-			return fmt.Errorf("%w: failed to set field %s value %v", err, path, value)
+			return fmt.Errorf("%w: failed to set field %s value %v", err, path, redactIfSecret(field, value))
 		}
 
 		return nil
 	})
 }
 
+// atomicWriter is the Writer f.writer returns when Options.AtomicFetch is enabled. Write stages its
+// field rather than committing it straight away, and records a FieldUpdate for Flush to apply
+// later; nothing reaches a field until Flush is called, so a Provider that errors partway through
+// its Values call can be undone in full with Rollback.
+type atomicWriter struct {
+	f        *Fido
+	provider Provider
+	updates  FieldUpdates
+}
+
+// newAtomicWriter constructs an atomicWriter batching values given to it on behalf of provider.
+func newAtomicWriter(f *Fido, provider Provider) *atomicWriter {
+	return &atomicWriter{f: f, provider: provider}
+}
+
+// Write stages value onto the field at path, without committing it, and records the staged change
+// as a FieldUpdate for Flush to commit or Rollback to discard.
+func (w *atomicWriter) Write(path Path, value interface{}) error {
+	field, ok := w.f.fields.get(path)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrFieldNotFound, field)
+	}
+
+	current := field.Value().Interface()
+
+	if reflect.DeepEqual(value, current) {
+		return nil
+	}
+
+	if err := field.Stage(value, w.provider); err != nil {
+		return fmt.Errorf("%w: failed to stage field %s value %v", err, path, redactIfSecret(field, value))
+	}
+
+	w.updates = append(w.updates, &FieldUpdate{
+		Path:     path,
+		Old:      current,
+		New:      value,
+		Provider: w.provider,
+		Secret:   isSecretField(field),
+	})
+
+	return nil
+}
+
+// Flush commits every field staged by Write, in the same all-or-nothing fashion as fetch's own
+// commit: a field that fails to commit, or fails validation, reverts every field committed earlier
+// in the batch and discards the rest, so the destination struct is left exactly as it was. On
+// success it returns the committed FieldUpdates, ready to publish to subscribers.
+func (w *atomicWriter) Flush() (FieldUpdates, error) {
+	updates := w.updates
+	w.updates = nil
+
+	if err := w.f.commit(updates); err != nil {
+		return nil, err
+	}
+
+	return updates, nil
+}
+
+// Rollback discards every field staged by Write without committing any of them.
+func (w *atomicWriter) Rollback() {
+	w.f.discard(w.updates)
+	w.updates = nil
+}
+
 func (f *Fido) initMapMiddleware() WriterMiddleware {
 	return WriterMiddleware(func(next Writer) Writer {
 		return WriterFunc(func(path Path, value interface{}) error {
@@ -93,6 +165,7 @@ func (f *Fido) notificationMiddleware(provider Provider, ch chan<- *FieldUpdate)
 					Old:      current,
 					New:      value,
 					Provider: provider,
+					Secret:   isSecretField(field),
 				}
 			}
 
@@ -119,3 +192,25 @@ func (f *Fido) enforcePriorityMiddleware(provider Provider) WriterMiddleware {
 		})
 	})
 }
+
+// transformMiddleware runs value through the field's transform= names, in order, against the
+// Transforms registered with WithTransform, short-circuiting the chain with a wrapped
+// ErrSetInvalidValue if a name has no Transform registered or the Transform itself rejects the
+// value. It is composed after enforcePriorityMiddleware and before the terminal writer.
+func (f *Fido) transformMiddleware() WriterMiddleware {
+	return WriterMiddleware(func(next Writer) Writer {
+		return WriterFunc(func(path Path, value interface{}) error {
+			field, ok := f.fields.get(path)
+			if !ok {
+				return fmt.Errorf("%w: %s", ErrFieldNotFound, field)
+			}
+
+			transformed, err := f.transformValue(field, path, value)
+			if err != nil {
+				return err
+			}
+
+			return next.Write(path, transformed)
+		})
+	})
+}
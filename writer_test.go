@@ -0,0 +1,173 @@
+package fido
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_Fido_writer(t *testing.T) {
+	p := NewTestProvider(t)
+
+	t.Run("AppliesAChangedValue", func(t *testing.T) {
+		t.Parallel()
+
+		v := reflect.New(reflect.TypeOf("")).Elem()
+
+		f := &Fido{fields: fields{}}
+		f.fields.set(Path{"foo"}, &field{path: Path{"foo"}, value: v})
+
+		w := f.writer(context.Background(), p)
+
+		if err := w.Write(Path{"foo"}, "bar"); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if want := "bar"; !reflect.DeepEqual(want, v.Interface()) {
+			t.Errorf("want %+v, got %+v", want, v.Interface())
+		}
+	})
+
+	t.Run("SkipsAnUnchangedValue", func(t *testing.T) {
+		t.Parallel()
+
+		v := reflect.New(reflect.TypeOf("")).Elem()
+		v.SetString("bar")
+
+		fld := &field{path: Path{"foo"}, value: v}
+
+		f := &Fido{fields: fields{}}
+		f.fields.set(Path{"foo"}, fld)
+
+		w := f.writer(context.Background(), p)
+
+		if err := w.Write(Path{"foo"}, "bar"); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if fld.Provider() != nil {
+			t.Errorf("want no provider recorded for an unchanged value, got %+v", fld.Provider())
+		}
+	})
+
+	t.Run("AppliesAChangedSliceValueWithoutPanicking", func(t *testing.T) {
+		t.Parallel()
+
+		v := reflect.New(reflect.TypeOf([]string(nil))).Elem()
+
+		f := &Fido{fields: fields{}}
+		f.fields.set(Path{"tags"}, &field{path: Path{"tags"}, value: v})
+
+		w := f.writer(context.Background(), p)
+
+		if err := w.Write(Path{"tags"}, []string{"a", "b"}); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if want := []string{"a", "b"}; !reflect.DeepEqual(want, v.Interface()) {
+			t.Errorf("want %+v, got %+v", want, v.Interface())
+		}
+	})
+}
+
+func Test_atomicWriter(t *testing.T) {
+	p := NewTestProvider(t)
+
+	newFields := func() (a, b reflect.Value, flds fields) {
+		a = reflect.New(reflect.TypeOf(0)).Elem()
+		b = reflect.New(reflect.TypeOf(0)).Elem()
+		b.SetInt(5)
+
+		fa := &field{path: Path{"a"}, value: a}
+		fb := &field{path: Path{"b"}, value: b, validates: []string{"min=10"}}
+
+		flds = fields{}
+		flds.set(Path{"a"}, fa)
+		flds.set(Path{"b"}, fb)
+
+		return a, b, flds
+	}
+
+	t.Run("FlushCommitsEveryStagedField", func(t *testing.T) {
+		t.Parallel()
+
+		a, b, flds := newFields()
+		f := &Fido{fields: flds}
+
+		w := newAtomicWriter(f, p)
+
+		if err := w.Write(Path{"a"}, 1); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if err := w.Write(Path{"b"}, 12); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if _, err := w.Flush(); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if want := 1; !reflect.DeepEqual(want, a.Interface()) {
+			t.Errorf("want %+v, got %+v", want, a.Interface())
+		}
+
+		if want := 12; !reflect.DeepEqual(want, b.Interface()) {
+			t.Errorf("want %+v, got %+v", want, b.Interface())
+		}
+	})
+
+	t.Run("FlushRevertsEarlierFieldsWhenValidationFails", func(t *testing.T) {
+		t.Parallel()
+
+		a, b, flds := newFields()
+		f := &Fido{fields: flds}
+
+		w := newAtomicWriter(f, p)
+
+		if err := w.Write(Path{"a"}, 1); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		if err := w.Write(Path{"b"}, 3); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		_, err := w.Flush()
+		if !errors.Is(err, ErrValidation) {
+			t.Fatalf("want ErrValidation, got %+v", err)
+		}
+
+		if want := 0; !reflect.DeepEqual(want, a.Interface()) {
+			t.Errorf("want field a reverted to %+v, got %+v", want, a.Interface())
+		}
+
+		if want := 5; !reflect.DeepEqual(want, b.Interface()) {
+			t.Errorf("want field b left at %+v, got %+v", want, b.Interface())
+		}
+	})
+
+	t.Run("RollbackDiscardsEveryStagedFieldWithoutCommitting", func(t *testing.T) {
+		t.Parallel()
+
+		a, _, flds := newFields()
+		f := &Fido{fields: flds}
+
+		w := newAtomicWriter(f, p)
+
+		if err := w.Write(Path{"a"}, 1); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		w.Rollback()
+
+		if want := 0; !reflect.DeepEqual(want, a.Interface()) {
+			t.Errorf("want field a left at %+v, got %+v", want, a.Interface())
+		}
+
+		if len(w.updates) != 0 {
+			t.Errorf("want no pending updates after rollback, got %+v", w.updates)
+		}
+	})
+}